@@ -42,6 +42,11 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 	scheme.AddKnownTypes(GroupVersion,
 		&TestRun{}, &TestRunList{},
 		&PrivateLoadZone{}, &PrivateLoadZoneList{},
+		&ScriptGrant{}, &ScriptGrantList{},
+		&TestRunSchedule{}, &TestRunScheduleList{},
+		&TestRunTemplate{}, &TestRunTemplateList{},
+		&ClusterTestRunTemplate{}, &ClusterTestRunTemplateList{},
+		&TestSuite{}, &TestSuiteList{},
 	)
 
 	metav1.AddToGroupVersion(scheme, GroupVersion)
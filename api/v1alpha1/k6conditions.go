@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/grafana/k6-operator/pkg/types"
+	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -17,9 +18,23 @@ const (
 	TestRunRunning = "TestRunRunning"
 
 	// TeardownExecuted indicates whether the `teardown()` has been executed on one of the runners.
-	// This condition can be used only in PLZ test runs.
+	// This condition can be used only in PLZ test runs or with `.spec.setupTeardown: once`.
 	TeardownExecuted = "TeardownExecuted"
 
+	// SetupSucceeded indicates the outcome of `setup()` executed by the operator.
+	// This condition can be used only in PLZ test runs or with `.spec.setupTeardown: once`.
+	// - if empty / Unknown, setup was not executed yet
+	// - if False, setup has failed and the message contains the error
+	// - if True, setup has succeeded and its data was sent to all runners
+	SetupSucceeded = "SetupSucceeded"
+
+	// TeardownSucceeded indicates the outcome of `teardown()` executed by the operator.
+	// This condition can be used only in PLZ test runs or with `.spec.setupTeardown: once`.
+	// - if empty / Unknown, teardown was not executed yet
+	// - if False, teardown has failed and the message contains the error
+	// - if True, teardown has succeeded
+	TeardownSucceeded = "TeardownSucceeded"
+
 	// CloudTestRun indicates if this test run is supposed to be a cloud test run
 	// (i.e. with `--out cloud` option).
 	// - if empty / Unknown, the type of test is unknown yet
@@ -55,6 +70,39 @@ const (
 	// - if False, it's a PLZ test run and it wasn't aborted.
 	// - if True, it is a PLZ test run and it was aborted.
 	CloudTestRunAborted = "CloudTestRunAborted"
+
+	// TestRunFailed indicates if the test run has failed, either before reaching
+	// the end of execution, e.g. because of an exceeded timeout, or with a result
+	// other than Passed. The reason and message of the condition describe the failure.
+	// - if empty / Unknown / False, no failure was detected
+	// - if True, the test run has failed
+	TestRunFailed = "TestRunFailed"
+
+	// TestRunSucceeded indicates if all runners have finished with the Passed result.
+	// - if empty / Unknown, the result is not known yet
+	// - if False, the test run has failed: see TestRunFailed for details
+	// - if True, the test run has passed
+	TestRunSucceeded = "TestRunSucceeded"
+
+	// TestRunStoppedByUser indicates if the test run was stopped before
+	// its end with the StopAnnotation.
+	// - if empty / Unknown / False, the test run was not stopped by user
+	// - if True, the stop was requested and the runners were told to stop
+	TestRunStoppedByUser = "TestRunStoppedByUser"
+
+	// TestRunPaused indicates if the runners were paused after the start
+	// of the test run, with .spec.paused.
+	// - if empty / Unknown, the test run has not started yet
+	// - if False, the runners are executing the test
+	// - if True, the runners are paused
+	TestRunPaused = "TestRunPaused"
+
+	// ThresholdsPassed indicates the outcome of thresholds evaluated by the operator.
+	// This condition can be used only with `.spec.evaluateThresholds`.
+	// - if empty / Unknown, thresholds were not evaluated yet or some of them have no values
+	// - if False, some thresholds have failed and the message lists them
+	// - if True, all thresholds have passed so far
+	ThresholdsPassed = "ThresholdsPassed"
 )
 
 // Initialize defines only conditions common to all test runs.
@@ -104,6 +152,11 @@ func UpdateCondition(k6 *TestRun, conditionType string, conditionStatus metav1.C
 	types.UpdateCondition(&k6.GetStatus().Conditions, conditionType, conditionStatus)
 }
 
+// SetCondition is like UpdateCondition but with a custom reason and message.
+func SetCondition(k6 *TestRun, conditionType string, conditionStatus metav1.ConditionStatus, reason, message string) {
+	types.SetCondition(&k6.GetStatus().Conditions, conditionType, conditionStatus, reason, message)
+}
+
 func IsTrue(k6 *TestRun, conditionType string) bool {
 	return meta.IsStatusConditionTrue(k6.GetStatus().Conditions, conditionType)
 }
@@ -150,6 +203,7 @@ func (k6status *TestRunStatus) SetIfNewer(proposedStatus TestRunStatus) (isNewer
 
 	// If a change in stage is proposed, confirm that it is consistent with
 	// expected flow of any test run.
+	oldStage := k6status.Stage
 	if k6status.Stage != proposedStatus.Stage && len(proposedStatus.Stage) > 0 {
 		switch k6status.Stage {
 		case "", "initialization":
@@ -186,5 +240,79 @@ func (k6status *TestRunStatus) SetIfNewer(proposedStatus TestRunStatus) (isNewer
 		}
 	}
 
+	// The result is final: it is set only once.
+	if len(proposedStatus.Result) > 0 && len(k6status.Result) == 0 {
+		k6status.Result = proposedStatus.Result
+		k6status.RunnerResults = proposedStatus.RunnerResults
+		isNewer = true
+	}
+
+	// The commit of the script is resolved once, by the initializer.
+	if len(proposedStatus.ScriptCommit) > 0 && len(k6status.ScriptCommit) == 0 {
+		k6status.ScriptCommit = proposedStatus.ScriptCommit
+		isNewer = true
+	}
+
+	// The template is resolved once, at the start of the test run.
+	if proposedStatus.ResolvedSpec != nil && k6status.ResolvedSpec == nil {
+		k6status.ResolvedSpec = proposedStatus.ResolvedSpec
+		isNewer = true
+	}
+
+	if len(proposedStatus.SummaryConfigMap) > 0 && len(k6status.SummaryConfigMap) == 0 {
+		k6status.SummaryConfigMap = proposedStatus.SummaryConfigMap
+		isNewer = true
+	}
+
+	// Thresholds are re-evaluated during the test run, so the latest
+	// evaluation always replaces the previous one.
+	if len(proposedStatus.Thresholds) > 0 && !equality.Semantic.DeepEqual(k6status.Thresholds, proposedStatus.Thresholds) {
+		k6status.Thresholds = proposedStatus.Thresholds
+		isNewer = true
+	}
+
+	if proposedStatus.ObservedPaused != nil &&
+		(k6status.ObservedPaused == nil || *k6status.ObservedPaused != *proposedStatus.ObservedPaused) {
+		k6status.ObservedPaused = proposedStatus.ObservedPaused
+		isNewer = true
+	}
+
+	if proposedStatus.SyncedPaused != nil &&
+		(k6status.SyncedPaused == nil || *k6status.SyncedPaused != *proposedStatus.SyncedPaused) {
+		k6status.SyncedPaused = proposedStatus.SyncedPaused
+		isNewer = true
+	}
+
+	if len(proposedStatus.LastError) > 0 && k6status.LastError != proposedStatus.LastError {
+		k6status.LastError = proposedStatus.LastError
+		isNewer = true
+	}
+
+	if k6status.Runners != proposedStatus.Runners {
+		k6status.Runners = proposedStatus.Runners
+		isNewer = true
+	}
+
+	if proposedStatus.ObservedGeneration > k6status.ObservedGeneration {
+		k6status.ObservedGeneration = proposedStatus.ObservedGeneration
+		isNewer = true
+	}
+
+	if k6status.Stage != oldStage {
+		now := metav1.Now()
+		k6status.StageTransitionTime = &now
+
+		switch k6status.Stage {
+		case "started":
+			if k6status.StartTime == nil {
+				k6status.StartTime = &now
+			}
+		case "finished", "error":
+			if k6status.CompletionTime == nil {
+				k6status.CompletionTime = &now
+			}
+		}
+	}
+
 	return
 }
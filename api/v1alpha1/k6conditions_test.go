@@ -0,0 +1,32 @@
+package v1alpha1
+
+import (
+	"testing"
+)
+
+func Test_SetIfNewer_StageTimes(t *testing.T) {
+	t.Parallel()
+
+	status := TestRunStatus{Stage: "created"}
+
+	if !status.SetIfNewer(TestRunStatus{Stage: "started"}) {
+		t.Fatal("expected the change of stage to be accepted")
+	}
+	if status.StartTime == nil || status.CompletionTime != nil {
+		t.Fatalf("expected only start time to be set, got %v and %v", status.StartTime, status.CompletionTime)
+	}
+	startTime := *status.StartTime
+
+	status.SetIfNewer(TestRunStatus{Stage: "stopped", LastError: "runner has failed"})
+	status.SetIfNewer(TestRunStatus{Stage: "finished"})
+
+	if !status.StartTime.Equal(&startTime) {
+		t.Errorf("expected start time to stay %v, got %v", startTime, status.StartTime)
+	}
+	if status.CompletionTime == nil {
+		t.Error("expected completion time to be set")
+	}
+	if status.LastError != "runner has failed" {
+		t.Errorf("expected last error to be kept, got %q", status.LastError)
+	}
+}
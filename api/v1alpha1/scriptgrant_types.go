@@ -0,0 +1,92 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ScriptGrantSpec defines which TestRuns from other namespaces may reference
+// scripts in the namespace of the ScriptGrant.
+type ScriptGrantSpec struct {
+	// From are the namespaces of TestRuns which may reference the scripts.
+	// +kubebuilder:validation:MinItems=1
+	// +listType=atomic
+	From []ScriptGrantFrom `json:"from"`
+	// To are the objects which may be referenced.
+	// +kubebuilder:validation:MinItems=1
+	// +listType=atomic
+	To []ScriptGrantTo `json:"to"`
+}
+
+// ScriptGrantFrom describes the TestRuns which may reference the scripts.
+type ScriptGrantFrom struct {
+	// Namespace of the TestRuns.
+	// +kubebuilder:validation:MinLength=1
+	Namespace string `json:"namespace"`
+}
+
+// ScriptGrantTo describes the objects which may be referenced.
+type ScriptGrantTo struct {
+	// Kind of the object. Only ConfigMap is supported.
+	// +kubebuilder:validation:Enum=ConfigMap
+	Kind string `json:"kind"`
+	// Name of the object. If omitted, all objects of the kind may be referenced.
+	// +optional
+	Name string `json:"name,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// ScriptGrant permits TestRuns from other namespaces to reference scripts
+// in its namespace, in the style of ReferenceGrant of Gateway API.
+type ScriptGrant struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ScriptGrantSpec `json:"spec,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// ScriptGrantList contains a list of ScriptGrant
+type ScriptGrantList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ScriptGrant `json:"items"`
+}
+
+// Permits checks whether a TestRun from the namespace may reference
+// the object of the given kind and name.
+func (g *ScriptGrant) Permits(namespace, kind, name string) bool {
+	fromNamespace := false
+	for _, from := range g.Spec.From {
+		if from.Namespace == namespace {
+			fromNamespace = true
+			break
+		}
+	}
+	if !fromNamespace {
+		return false
+	}
+
+	for _, to := range g.Spec.To {
+		if to.Kind == kind && (len(to.Name) == 0 || to.Name == name) {
+			return true
+		}
+	}
+	return false
+}
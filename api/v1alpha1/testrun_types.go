@@ -16,12 +16,17 @@ package v1alpha1
 
 import (
 	"errors"
+	"fmt"
+	"net/url"
+	"path"
 	"path/filepath"
 	"slices"
+	"strconv"
 	"strings"
 
 	"github.com/grafana/k6-operator/pkg/types"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	k8stypes "k8s.io/apimachinery/pkg/types"
@@ -36,7 +41,9 @@ type PodMetadata struct {
 type Pod struct {
 	// Disabled is supported only for initializer pod, and it allows to skip initializer execution.
 	// Use it when absolutely certain k6 script is valid and set up correctly in Kubernetes.
-	// It is ignored by cloud output test runs, as they depend on initializer.
+	// It is ignored by cloud output test runs, as they depend on initializer,
+	// by test runs with evaluateThresholds, as thresholds are read by the initializer,
+	// and by scripts from git, HTTP, OCI or a bundle, whose failures are reported by it.
 	// +optional
 	// +kubebuilder:default=false
 	Disabled                     bool                              `json:"disabled,omitempty"`
@@ -92,10 +99,23 @@ type K6Scuttle struct {
 
 // TestRunSpec defines the desired state of TestRun
 type TestRunSpec struct {
+	// TemplateRef refers to the template the spec is merged with.
+	// Fields set in the TestRun override the fields of the template:
+	// objects and maps are merged by keys, lists of items with a name,
+	// e.g. env and volumes, are merged by the name, while other lists and
+	// the script are replaced as a whole. Fields set to an empty value,
+	// zero or false do not override the template.
+	// +optional
+	TemplateRef *TestRunTemplateReference `json:"templateRef,omitempty"`
+
 	// Script describes where the k6 script is located.
+	// It is required unless it is set by the template.
+	// +optional
 	Script K6Script `json:"script"`
 
 	// Parallelism shows the number of k6 runners.
+	// It is required unless it is set by the template.
+	// +optional
 	Parallelism int32 `json:"parallelism"`
 
 	// Separate is a quick way to run all k6 runners on different hostnames
@@ -119,6 +139,14 @@ type TestRunSpec struct {
 	// is configured with the same parameters as a runner Pod.
 	Initializer *Pod `json:"initializer,omitempty"`
 
+	// Archive makes the initializer store k6 archive of the script in
+	// a PersistentVolumeClaim owned by the TestRun. Runners then run that
+	// archive instead of the script, so all of them execute the same
+	// resolved bundle and remote modules are fetched only once.
+	// The initializer is run even if it is disabled.
+	// +optional
+	Archive *ScriptArchive `json:"archive,omitempty"`
+
 	// Configuration for the starter Pod.
 	Starter Pod `json:"starter,omitempty"`
 
@@ -131,6 +159,8 @@ type TestRunSpec struct {
 
 	// Paused is a boolean variable that allows to switch off passing the `--paused` to k6.
 	// Use with caution as it can skew the result of the test.
+	// Once the test run has started, changing this field pauses ("true")
+	// or resumes ("false") all runners.
 	// +kubebuilder:default="true"
 	Paused string `json:"paused,omitempty"`
 
@@ -140,8 +170,43 @@ type TestRunSpec struct {
 	// +optional
 	Scuttle K6Scuttle `json:"scuttle,omitempty"`
 
+	// Cleanup with `post` deletes the TestRun as soon as it has finished.
+	// +optional
 	Cleanup Cleanup `json:"cleanup,omitempty"`
 
+	// CleanupPolicy defines how the TestRun and its resources are cleaned
+	// up after the end of the test run. It cannot be combined with .spec.cleanup.
+	// +optional
+	CleanupPolicy *CleanupPolicy `json:"cleanupPolicy,omitempty"`
+
+	// SetupTeardown defines how `setup()` and `teardown()` are executed.
+	// By default, each runner executes them. With `once`, setup is executed
+	// on one runner and its data is passed to all runners, while teardown
+	// is executed once after all runners have stopped.
+	// +optional
+	SetupTeardown SetupTeardown `json:"setupTeardown,omitempty"`
+
+	// CollectSummary enables collection of end-of-test summaries from all runners
+	// into a ConfigMap, named in .status.summaryConfigMap. Runners are kept alive
+	// after the end of the test until the summaries are retrieved.
+	// +optional
+	CollectSummary bool `json:"collectSummary,omitempty"`
+
+	// EvaluateThresholds makes the operator evaluate thresholds against metrics
+	// of all runners, instead of each runner evaluating them against its own
+	// share of the traffic. The verdict is in the ThresholdsPassed condition.
+	// Runners are kept alive after the end of the test for the final evaluation.
+	// Percentiles other than p(90) and p(95) are not exposed by k6 REST API,
+	// so a test run with a threshold on them fails at initialization.
+	// It has no effect in PLZ test runs, where thresholds are evaluated by k6 Cloud.
+	// +optional
+	EvaluateThresholds bool `json:"evaluateThresholds,omitempty"`
+
+	// Timeouts limit the duration of the stages of the test run.
+	// When a limit is exceeded, the TestRun is moved to the `error` stage.
+	// +optional
+	Timeouts *TestRunTimeouts `json:"timeouts,omitempty"`
+
 	// TestRunID is reserved by Grafana Cloud k6. Do not set it manually.
 	TestRunID string `json:"testRunId,omitempty"` // PLZ reserved field
 
@@ -155,6 +220,23 @@ type K6Script struct {
 	ConfigMap   K6Configmap   `json:"configMap,omitempty"`
 	// LocalFile describes the location of the script in the runner image.
 	LocalFile string `json:"localFile,omitempty"`
+	// Git describes the location of the script in a git repository.
+	// +optional
+	Git *K6GitScript `json:"git,omitempty"`
+	// HTTP describes the URL to download the script from.
+	// +optional
+	HTTP *K6HTTPScript `json:"http,omitempty"`
+	// OCI describes the OCI artifact with the script.
+	// +optional
+	OCI *K6OCIScript `json:"oci,omitempty"`
+	// Inline is the content of the script, stored as `/test/test.js`.
+	// It is copied into the spec of every k6 Pod, so it is meant for small scripts.
+	// +optional
+	Inline string `json:"inline,omitempty"`
+	// Bundle describes the ConfigMaps and Secrets which are laid out as
+	// a folder tree with the script and its modules.
+	// +optional
+	Bundle *K6ScriptBundle `json:"bundle,omitempty"`
 }
 
 // K6VolumeClaim describes the location of the script on the Volume.
@@ -169,20 +251,219 @@ type K6VolumeClaim struct {
 	ReadOnly bool `json:"readOnly,omitempty"`
 }
 
+// K6GitScript describes the location of the script in a git repository.
+// The repository is cloned by an init container into the `/test` folder of all k6 Pods.
+type K6GitScript struct {
+	// URL of the repository, over HTTPS, SSH or the git protocol.
+	// +kubebuilder:validation:MinLength=1
+	URL string `json:"url"`
+	// Ref is the branch or the tag to check out. If both Ref and Commit are
+	// omitted, the default branch of the repository is checked out.
+	// +optional
+	Ref string `json:"ref,omitempty"`
+	// Commit is the SHA of the commit to check out. It takes precedence over Ref.
+	// +optional
+	Commit string `json:"commit,omitempty"`
+	// Path is the directory in the repository where the script is located.
+	// +optional
+	Path string `json:"path,omitempty"`
+	// Name of the file to execute (.js or .tar), relative to Path.
+	// +optional
+	File string `json:"file,omitempty"`
+	// SecretName is the name of the Secret with the credentials of the repository:
+	// `token` (and optionally `username`) for HTTPS, or `ssh-privatekey`
+	// and `known_hosts` for SSH. The host key of the server must be in
+	// `known_hosts`, otherwise the clone fails.
+	// +optional
+	SecretName string `json:"secretName,omitempty"`
+	// Image with git to clone the repository. Defaults to alpine/git:v2.47.2.
+	// +optional
+	Image string `json:"image,omitempty"`
+}
+
+// K6HTTPScript describes the script to download by HTTP(S).
+// It is downloaded by an init container into the `/test` folder of all k6 Pods.
+type K6HTTPScript struct {
+	// URL of the script (.js or .tar).
+	// +kubebuilder:validation:MinLength=1
+	URL string `json:"url"`
+	// SHA256 is the expected checksum of the script, in hex. The test run
+	// fails if the downloaded script does not match it.
+	// +kubebuilder:validation:Pattern=`^[a-fA-F0-9]{64}$`
+	// +optional
+	SHA256 string `json:"sha256,omitempty"`
+	// Name of the file to store the script as. Defaults to the last
+	// segment of the URL path, or test.js.
+	// +optional
+	File string `json:"file,omitempty"`
+	// HeadersSecretName is the name of the Secret with the headers of the request,
+	// e.g. `Authorization`. Each key of the Secret is sent as a header.
+	// +optional
+	HeadersSecretName string `json:"headersSecretName,omitempty"`
+	// Image with curl to download the script. Defaults to the starter image.
+	// +optional
+	Image string `json:"image,omitempty"`
+}
+
+// K6OCIScript describes the script stored as an OCI artifact.
+// The files of the artifact are pulled by an init container into the `/test` folder of all k6 Pods.
+type K6OCIScript struct {
+	// Reference of the artifact, e.g. `registry.example.com/tests/api:v1`.
+	// Use a digest, e.g. `registry.example.com/tests/api@sha256:...`, to pin the content.
+	// +kubebuilder:validation:MinLength=1
+	Reference string `json:"reference"`
+	// Name of the file to execute (.js or .tar) in the artifact.
+	// Can include a path component (e.g., "subdir/script.js").
+	// +optional
+	File string `json:"file,omitempty"`
+	// SecretName is the name of the `kubernetes.io/dockerconfigjson` Secret
+	// with the credentials of the registry.
+	// +optional
+	SecretName string `json:"secretName,omitempty"`
+	// Image with oras to pull the artifact. Defaults to ghcr.io/oras-project/oras.
+	// +optional
+	Image string `json:"image,omitempty"`
+}
+
+// K6ScriptBundle describes the script with its modules, stored in ConfigMaps and Secrets.
+// They are laid out by an init container in the `/test` folder of all k6 Pods.
+type K6ScriptBundle struct {
+	// Sources of the bundle, laid out in order.
+	// +kubebuilder:validation:MinItems=1
+	// +listType=atomic
+	Sources []K6BundleSource `json:"sources"`
+	// Name of the file to execute (.js or .tar), relative to the root of the bundle.
+	// Can include a path component (e.g., "subdir/script.js").
+	// +optional
+	File string `json:"file,omitempty"`
+}
+
+// K6BundleSource is a ConfigMap or a Secret with files of the bundle.
+type K6BundleSource struct {
+	// ConfigMap is the name of the ConfigMap with the files.
+	// +optional
+	ConfigMap string `json:"configMap,omitempty"`
+	// Secret is the name of the Secret with the files.
+	// +optional
+	Secret string `json:"secret,omitempty"`
+	// Dir is the folder of the bundle where the files are stored, e.g. `lib`.
+	// Defaults to the root of the bundle.
+	// +optional
+	Dir string `json:"dir,omitempty"`
+	// Archive is the key with a gzipped tar, e.g. in `binaryData` of the ConfigMap.
+	// If set, the archive is unpacked into Dir instead of copying the keys.
+	// +optional
+	Archive string `json:"archive,omitempty"`
+}
+
 // K6Configmap describes the location of the script in the ConfigMap.
 type K6Configmap struct {
-	// Name of the ConfigMap. It is expected to be in the sanme namespace as the `TestRun`.
+	// Name of the ConfigMap. It is expected to be in the sanme namespace as the `TestRun`,
+	// unless Namespace is set.
 	Name string `json:"name"`
+	// Namespace of the ConfigMap, if it is not the namespace of the `TestRun`.
+	// It requires a ScriptGrant in that namespace which permits the reference.
+	// The ConfigMap is then copied into the namespace of the `TestRun`.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
 	// Name of the file to execute (.js or .tar), stored as a key in the ConfigMap.
 	File string `json:"file,omitempty"`
 }
 
+// ScriptArchive describes the PersistentVolumeClaim for k6 archive.
+// Runners are usually scheduled to different nodes, so the storage class
+// must support the access mode, ReadWriteMany by default.
+type ScriptArchive struct {
+	// StorageClassName of the PersistentVolumeClaim.
+	// If omitted, the default storage class is used.
+	// +optional
+	StorageClassName *string `json:"storageClassName,omitempty"`
+	// Size of the PersistentVolumeClaim. Defaults to 100Mi.
+	// +optional
+	Size *resource.Quantity `json:"size,omitempty"`
+	// AccessModes of the PersistentVolumeClaim. Defaults to ReadWriteMany.
+	// +optional
+	// +listType=atomic
+	AccessModes []corev1.PersistentVolumeAccessMode `json:"accessModes,omitempty"`
+}
+
+// TestRunTimeouts describes the limits for the stages of the test run.
+// A zero or omitted value means there is no limit.
+type TestRunTimeouts struct {
+	// Initialization limits the time for the initializer to inspect the script.
+	// +optional
+	Initialization *metav1.Duration `json:"initialization,omitempty"`
+	// RunnersReady limits the time for all runner Pods to get ready
+	// after the runner Jobs were created.
+	// +optional
+	RunnersReady *metav1.Duration `json:"runnersReady,omitempty"`
+	// Start limits the time for the starter Job to start the test on all runners.
+	// +optional
+	Start *metav1.Duration `json:"start,omitempty"`
+	// Run limits the total duration of the test execution,
+	// from the start until all runners have finished.
+	// +optional
+	Run *metav1.Duration `json:"run,omitempty"`
+}
+
 //TODO: cleanup pre-execution?
 
 // Cleanup allows for automatic cleanup of resources post execution.
 // +kubebuilder:validation:Enum=post
 type Cleanup string
 
+// CleanupPolicyType defines what is cleaned up after the end of the test run.
+// +kubebuilder:validation:Enum=DeleteResources;DeleteTestRun;KeepLastN
+type CleanupPolicyType string
+
+const (
+	// CleanupDeleteResources keeps the TestRun, but deletes its Jobs
+	// and Services once the TTL has passed.
+	CleanupDeleteResources CleanupPolicyType = "DeleteResources"
+	// CleanupDeleteTestRun deletes the TestRun together with all
+	// its resources once the TTL has passed.
+	CleanupDeleteTestRun CleanupPolicyType = "DeleteTestRun"
+	// CleanupKeepLastN deletes finished TestRuns which have the same
+	// value of the group label and the KeepLastN policy, except for
+	// the last N of them.
+	CleanupKeepLastN CleanupPolicyType = "KeepLastN"
+)
+
+// CleanupPolicy defines cleanup after the end of the test run.
+type CleanupPolicy struct {
+	Type CleanupPolicyType `json:"type"`
+
+	// TTL is the time after the end of the test run before the cleanup.
+	// It is used with DeleteResources and DeleteTestRun.
+	// +optional
+	TTL *metav1.Duration `json:"ttl,omitempty"`
+
+	// KeepLast is the number of finished TestRuns to keep with KeepLastN.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=1
+	// +optional
+	KeepLast int32 `json:"keepLast,omitempty"`
+
+	// GroupLabel is the key of the label which groups TestRuns with KeepLastN:
+	// TestRuns with the same value of this label are counted together.
+	// +optional
+	GroupLabel string `json:"groupLabel,omitempty"`
+
+	// KeepFailed excludes failed test runs from the cleanup, so that
+	// they can be debugged.
+	// +optional
+	KeepFailed bool `json:"keepFailed,omitempty"`
+}
+
+// SetupTeardown defines the mode of `setup()` and `teardown()` execution.
+// +kubebuilder:validation:Enum=perRunner;once
+type SetupTeardown string
+
+const (
+	SetupTeardownPerRunner SetupTeardown = "perRunner"
+	SetupTeardownOnce      SetupTeardown = "once"
+)
+
 // Stage describes which stage of the test execution lifecycle k6 runners are in.
 // +kubebuilder:validation:Enum=initialization;initialized;created;started;stopped;finished;error
 type Stage string
@@ -202,11 +483,134 @@ type TestRunStatus struct {
 	TestRunID string `json:"testRunId,omitempty"`
 	// +optional
 	AggregationVars string `json:"aggregationVars,omitempty"`
+	// StageTransitionTime is the last time the stage was changed.
+	// +optional
+	StageTransitionTime *metav1.Time `json:"stageTransitionTime,omitempty"`
+	// ObservedPaused shows whether the runners were paused when they were
+	// last synchronized with .spec.paused, after the test run has started.
+	// +optional
+	ObservedPaused *bool `json:"observedPaused,omitempty"`
+	// SyncedPaused is the value of .spec.paused when the runners were last
+	// synchronized with it: by the starter, which resumes them regardless of
+	// the value, or by a pause or resume call. Only later changes of
+	// .spec.paused pause or resume the runners.
+	// +optional
+	SyncedPaused *bool `json:"syncedPaused,omitempty"`
+	// Result is the outcome of the test run, known once all runners have finished.
+	// +optional
+	Result TestRunResult `json:"result,omitempty"`
+	// RunnerResults is the outcome of each runner.
+	// +optional
+	RunnerResults []RunnerResult `json:"runnerResults,omitempty"`
+	// SummaryConfigMap is the name of the ConfigMap with end-of-test summaries,
+	// if .spec.collectSummary is enabled.
+	// +optional
+	SummaryConfigMap string `json:"summaryConfigMap,omitempty"`
+	// Thresholds are the thresholds of the script and the outcome of their
+	// last evaluation, if .spec.evaluateThresholds is enabled.
+	// +optional
+	Thresholds []ThresholdStatus `json:"thresholds,omitempty"`
+	// StartTime is the time when the runners were started.
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+	// CompletionTime is the time when the test run has reached
+	// the finished or error stage.
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+	// Runners counts the runner pods in each state.
+	// +optional
+	Runners RunnersStatus `json:"runners,omitempty"`
+	// LastError is the message of the last error which has failed the test run.
+	// +optional
+	LastError string `json:"lastError,omitempty"`
+	// ObservedGeneration is the generation of the TestRun last processed by the operator.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// ScriptCommit is the SHA of the commit checked out by the initializer,
+	// if the script is stored in git. Runners check out the same commit.
+	// +optional
+	ScriptCommit string `json:"scriptCommit,omitempty"`
+	// ResolvedSpec is the spec merged with the template from .spec.templateRef.
+	// It is resolved once, at the start of the test run, and used instead of .spec,
+	// except for paused, timeouts, cleanup and cleanupPolicy which are read from .spec when set.
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:validation:Type=object
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +optional
+	ResolvedSpec *TestRunSpec `json:"resolvedSpec,omitempty"`
+}
+
+// RunnersStatus counts the runner pods of a test run.
+type RunnersStatus struct {
+	// Desired is the number of runners, i.e. .spec.parallelism.
+	Desired int32 `json:"desired"`
+	// Scheduled is the number of runner pods scheduled to a node.
+	Scheduled int32 `json:"scheduled"`
+	// Ready is the number of runner pods ready to serve k6 REST API.
+	Ready int32 `json:"ready"`
+	// Running is the number of runner pods in the Running phase.
+	Running int32 `json:"running"`
+	// Succeeded is the number of runner pods which have exited successfully.
+	Succeeded int32 `json:"succeeded"`
+	// Failed is the number of runner pods which have exited with an error.
+	Failed int32 `json:"failed"`
+}
+
+// TestRunResult is the outcome of a test run or of a single runner.
+// +kubebuilder:validation:Enum=Passed;ThresholdsFailed;ScriptError;Aborted;RunnerFailed
+type TestRunResult string
+
+const (
+	// ResultPassed means that k6 exited successfully.
+	ResultPassed TestRunResult = "Passed"
+	// ResultThresholdsFailed means that some thresholds have failed.
+	ResultThresholdsFailed TestRunResult = "ThresholdsFailed"
+	// ResultScriptError means that k6 could not execute the script as intended,
+	// e.g. because of an exception or an invalid configuration.
+	ResultScriptError TestRunResult = "ScriptError"
+	// ResultAborted means that the test run was stopped before its end.
+	ResultAborted TestRunResult = "Aborted"
+	// ResultRunnerFailed means that a runner has crashed, was killed
+	// or could not run k6 at all.
+	ResultRunnerFailed TestRunResult = "RunnerFailed"
+)
+
+// RunnerResult describes how a single runner has finished.
+type RunnerResult struct {
+	// Name of the runner Job.
+	Name string `json:"name"`
+	// ExitCode of the k6 container. It is empty if the container
+	// has not terminated.
+	// +optional
+	ExitCode *int32        `json:"exitCode,omitempty"`
+	Result   TestRunResult `json:"result"`
+}
+
+// ThresholdStatus describes a single threshold evaluated by the operator.
+type ThresholdStatus struct {
+	// Metric is the name of the metric, possibly with tags of a submetric.
+	Metric string `json:"metric"`
+	// Threshold is the threshold expression, e.g. `p(95)<200`.
+	Threshold string `json:"threshold"`
+	// AbortOnFail stops the test run as soon as the threshold fails.
+	// +optional
+	AbortOnFail bool `json:"abortOnFail,omitempty"`
+	// AbortGracePeriod is the time since the start of the test
+	// during which a failed threshold does not stop the test run.
+	// +optional
+	AbortGracePeriod *metav1.Duration `json:"abortGracePeriod,omitempty"`
+	// Passed is the outcome of the last evaluation. It is empty if
+	// the threshold could not be evaluated, e.g. because there are no
+	// samples of the metric yet.
+	// +optional
+	Passed *bool `json:"passed,omitempty"`
 }
 
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
 //+kubebuilder:printcolumn:name="Stage",type="string",JSONPath=".status.stage",description="Stage"
+//+kubebuilder:printcolumn:name="Ready",type="integer",JSONPath=".status.runners.ready",description="Ready runners"
+//+kubebuilder:printcolumn:name="Result",type="string",JSONPath=".status.result"
 //+kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 //+kubebuilder:printcolumn:name="TestRunID",type="string",JSONPath=".status.testRunId"
 
@@ -313,6 +717,130 @@ func (k6 TestRunSpec) ParseScript() (*types.Script, error) {
 		return s, nil
 	}
 
+	if spec.Git != nil {
+		// The repository is cloned into /test, so the path must not leave it.
+		if slices.Contains(strings.Split(spec.Git.Path, "/"), "..") {
+			return nil, fmt.Errorf("git path %q must not contain '..'", spec.Git.Path)
+		}
+
+		s.Name = spec.Git.URL
+		s.Path = filepath.Join("/test", spec.Git.Path) + "/"
+		s.Filename = "test.js"
+		if spec.Git.File != "" {
+			s.Filename = spec.Git.File
+		}
+
+		s.Source = &types.ScriptSource{
+			URL:        spec.Git.URL,
+			Revision:   spec.Git.Ref,
+			SecretName: spec.Git.SecretName,
+			Image:      spec.Git.Image,
+		}
+		if spec.Git.Commit != "" {
+			s.Source.Revision = spec.Git.Commit
+		}
+
+		s.Type = "Git"
+		return s, nil
+	}
+
+	if spec.HTTP != nil {
+		u, err := url.Parse(spec.HTTP.URL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid script URL: %w", err)
+		}
+		if u.Scheme != "http" && u.Scheme != "https" {
+			return nil, fmt.Errorf("script URL %q must be http or https", spec.HTTP.URL)
+		}
+
+		s.Name = spec.HTTP.URL
+		s.Path = "/test/"
+		s.Filename = path.Base(u.Path)
+		if spec.HTTP.File != "" {
+			s.Filename = spec.HTTP.File
+		}
+		if s.Filename == "" || s.Filename == "." || s.Filename == "/" {
+			s.Filename = "test.js"
+		}
+		if strings.Contains(s.Filename, "/") {
+			return nil, fmt.Errorf("script file %q must not contain a path", s.Filename)
+		}
+
+		s.Source = &types.ScriptSource{
+			URL:        spec.HTTP.URL,
+			SHA256:     strings.ToLower(spec.HTTP.SHA256),
+			SecretName: spec.HTTP.HeadersSecretName,
+			Image:      spec.HTTP.Image,
+		}
+
+		s.Type = "HTTP"
+		return s, nil
+	}
+
+	if spec.OCI != nil {
+		file := spec.OCI.File
+		if file == "" {
+			file = "test.js"
+		}
+		if slices.Contains(strings.Split(file, "/"), "..") {
+			return nil, fmt.Errorf("OCI file %q must not contain '..'", file)
+		}
+
+		s.Name = spec.OCI.Reference
+		s.Path, s.Filename = filepath.Split(filepath.Join("/test", file))
+		s.Source = &types.ScriptSource{
+			URL:        spec.OCI.Reference,
+			SecretName: spec.OCI.SecretName,
+			Image:      spec.OCI.Image,
+		}
+
+		s.Type = "OCI"
+		return s, nil
+	}
+
+	if spec.Inline != "" {
+		s.Name = "Inline"
+		s.Path = "/test/"
+		s.Filename = "test.js"
+		s.Inline = spec.Inline
+		s.Type = "Inline"
+		return s, nil
+	}
+
+	if spec.Bundle != nil {
+		if len(spec.Bundle.Sources) == 0 {
+			return nil, errors.New("bundle should contain at least one source")
+		}
+
+		file := spec.Bundle.File
+		if file == "" {
+			file = "test.js"
+		}
+		if slices.Contains(strings.Split(file, "/"), "..") {
+			return nil, fmt.Errorf("bundle file %q must not contain '..'", file)
+		}
+
+		for _, source := range spec.Bundle.Sources {
+			if (source.ConfigMap == "") == (source.Secret == "") {
+				return nil, errors.New("bundle source should contain one of: ConfigMap, Secret")
+			}
+			if slices.Contains(strings.Split(source.Dir, "/"), "..") {
+				return nil, fmt.Errorf("bundle dir %q must not contain '..'", source.Dir)
+			}
+			s.Bundle = append(s.Bundle, types.BundleItem{
+				ConfigMap: source.ConfigMap,
+				Secret:    source.Secret,
+				Dir:       source.Dir,
+				Archive:   source.Archive,
+			})
+		}
+
+		s.Name = "Bundle"
+		s.Path, s.Filename = filepath.Split(filepath.Join("/test", file))
+		s.Type = "Bundle"
+		return s, nil
+	}
+
 	if spec.LocalFile != "" {
 		s.Name = "LocalFile"
 		s.Type = "LocalFile"
@@ -320,7 +848,7 @@ func (k6 TestRunSpec) ParseScript() (*types.Script, error) {
 		return s, nil
 	}
 
-	return nil, errors.New("script definition should contain one of: ConfigMap, VolumeClaim, LocalFile")
+	return nil, errors.New("script definition should contain one of: ConfigMap, VolumeClaim, LocalFile, Git, HTTP, OCI, Inline, Bundle")
 }
 
 // TestRunI implementation for TestRun
@@ -328,14 +856,58 @@ func (k6 *TestRun) GetStatus() *TestRunStatus {
 	return &k6.Status
 }
 
+// GetSpec returns the spec resolved with the template, if the TestRun has one.
+// The resolved spec is frozen at the start, so fields which can be changed
+// during the test run are read with their own getters below.
 func (k6 *TestRun) GetSpec() *TestRunSpec {
+	if k6.Status.ResolvedSpec != nil {
+		return k6.Status.ResolvedSpec
+	}
 	return &k6.Spec
 }
 
+// GetPaused returns .spec.paused, or the value of the template if it is unset.
+func (k6 *TestRun) GetPaused() string {
+	if len(k6.Spec.Paused) > 0 {
+		return k6.Spec.Paused
+	}
+	return k6.GetSpec().Paused
+}
+
+// GetTimeouts returns .spec.timeouts, or the value of the template if it is unset.
+func (k6 *TestRun) GetTimeouts() *TestRunTimeouts {
+	if k6.Spec.Timeouts != nil {
+		return k6.Spec.Timeouts
+	}
+	return k6.GetSpec().Timeouts
+}
+
+// GetCleanup returns .spec.cleanup, or the value of the template if it is unset.
+func (k6 *TestRun) GetCleanup() Cleanup {
+	if len(k6.Spec.Cleanup) > 0 {
+		return k6.Spec.Cleanup
+	}
+	return k6.GetSpec().Cleanup
+}
+
+// GetCleanupPolicy returns .spec.cleanupPolicy, or the value of the template if it is unset.
+func (k6 *TestRun) GetCleanupPolicy() *CleanupPolicy {
+	if k6.Spec.CleanupPolicy != nil {
+		return k6.Spec.CleanupPolicy
+	}
+	return k6.GetSpec().CleanupPolicy
+}
+
 func (k6 *TestRun) NamespacedName() k8stypes.NamespacedName {
 	return k8stypes.NamespacedName{Namespace: k6.Namespace, Name: k6.Name}
 }
 
+// IsReferencingScript is true when the script ConfigMap is in another namespace.
+func (k6 *TestRun) IsReferencingScript() bool {
+	cm := k6.GetSpec().Script.ConfigMap
+	return len(cm.Name) > 0 && len(cm.Namespace) > 0 && cm.Namespace != k6.Namespace
+}
+
 // TestRunID is a tiny helper to get k6 Cloud test run ID.
 // PLZ test run will have test run ID as part of spec,
 // while cloud output test run as part of status.
@@ -363,3 +935,63 @@ func (k6 *TestRun) IsInitializerDisabled() bool {
 	}
 	return k6.GetSpec().Initializer.Disabled
 }
+
+// IsFetchingScript is true when an init container fetches the script from
+// a git repository, a URL, an OCI registry or a bundle. Failures of these
+// sources, like a checksum mismatch, are reported by the initializer.
+func (k6 *TestRun) IsFetchingScript() bool {
+	script := k6.GetSpec().Script
+	return script.Git != nil || script.HTTP != nil || script.OCI != nil || script.Bundle != nil
+}
+
+// IsReusingArchive is true when the runners run k6 archive stored by the initializer.
+func (k6 *TestRun) IsReusingArchive() bool {
+	return k6.GetSpec().Archive != nil
+}
+
+// StopAnnotation can be set to "true" on a running TestRun to stop it
+// gracefully: runners receive a stop call and finish the test with
+// the end-of-test summary.
+const StopAnnotation = "k6.io/stop"
+
+// IsFailed checks if the test run has ended with an error or has not passed.
+func (k6 *TestRun) IsFailed() bool {
+	return k6.GetStatus().Stage == "error" || IsTrue(k6, TestRunFailed)
+}
+
+// IsStopRequested checks if the user asked to stop the test run with StopAnnotation.
+func (k6 *TestRun) IsStopRequested() bool {
+	return k6.GetAnnotations()[StopAnnotation] == "true"
+}
+
+// IsPaused returns the value of .spec.paused as a boolean.
+func (k6 *TestRun) IsPaused() bool {
+	paused, _ := strconv.ParseBool(k6.GetPaused())
+	return paused
+}
+
+// IsPauseChanged checks if .spec.paused was changed since the last
+// time it was synchronized with the runners.
+func (k6 *TestRun) IsPauseChanged() bool {
+	synced := k6.GetStatus().SyncedPaused
+	return synced != nil && *synced != k6.IsPaused()
+}
+
+// IsSetupTeardownOnce checks if `setup()` and `teardown()` are executed
+// by the operator only once, instead of on each runner. It is always so
+// for PLZ test runs.
+func (k6 *TestRun) IsSetupTeardownOnce() bool {
+	return k6.GetSpec().SetupTeardown == SetupTeardownOnce || IsTrue(k6, CloudPLZTestRun)
+}
+
+// IsLingering checks if runners stay alive after the end of the test,
+// so that the operator can talk to them before they are stopped.
+func (k6 *TestRun) IsLingering() bool {
+	return k6.IsSetupTeardownOnce() || k6.GetSpec().CollectSummary || k6.IsEvaluatingThresholds()
+}
+
+// IsEvaluatingThresholds checks if thresholds are evaluated by the operator
+// across all runners, instead of by each runner separately.
+func (k6 *TestRun) IsEvaluatingThresholds() bool {
+	return k6.GetSpec().EvaluateThresholds && !IsTrue(k6, CloudPLZTestRun)
+}
@@ -182,6 +182,198 @@ func Test_ParseScript(t *testing.T) {
 				},
 			},
 		},
+		{
+			"Git",
+			false,
+			&types.Script{
+				Name:     "https://example.com/tests.git",
+				Path:     "/test/load/api/",
+				Filename: "main.js",
+				Type:     "Git",
+				Source: &types.ScriptSource{
+					URL:        "https://example.com/tests.git",
+					Revision:   "v1.0.0",
+					SecretName: "git-credentials",
+				},
+			},
+			&TestRunSpec{
+				Script: K6Script{
+					Git: &K6GitScript{
+						URL:        "https://example.com/tests.git",
+						Ref:        "v1.0.0",
+						Path:       "load/api",
+						File:       "main.js",
+						SecretName: "git-credentials",
+					},
+				},
+			},
+		},
+		{
+			"Git with commit and no path",
+			false,
+			&types.Script{
+				Name:     "https://example.com/tests.git",
+				Path:     "/test/",
+				Filename: "test.js",
+				Type:     "Git",
+				Source: &types.ScriptSource{
+					URL:      "https://example.com/tests.git",
+					Revision: "0123456789abcdef",
+				},
+			},
+			&TestRunSpec{
+				Script: K6Script{
+					Git: &K6GitScript{
+						URL:    "https://example.com/tests.git",
+						Ref:    "main",
+						Commit: "0123456789abcdef",
+					},
+				},
+			},
+		},
+		{
+			"HTTP",
+			false,
+			&types.Script{
+				Name:     "https://example.com/scripts/api.js?version=2",
+				Path:     "/test/",
+				Filename: "api.js",
+				Type:     "HTTP",
+				Source: &types.ScriptSource{
+					URL:        "https://example.com/scripts/api.js?version=2",
+					SHA256:     "2f0ed1a2c2b9e9e6a8b95b9e1c1e3f4d5a6b7c8d9e0f1a2b3c4d5e6f7a8b9c0d",
+					SecretName: "headers",
+				},
+			},
+			&TestRunSpec{
+				Script: K6Script{
+					HTTP: &K6HTTPScript{
+						URL:               "https://example.com/scripts/api.js?version=2",
+						SHA256:            "2F0ED1A2C2B9E9E6A8B95B9E1C1E3F4D5A6B7C8D9E0F1A2B3C4D5E6F7A8B9C0D",
+						HeadersSecretName: "headers",
+					},
+				},
+			},
+		},
+		{
+			"HTTP with no file in the URL",
+			false,
+			&types.Script{
+				Name:     "https://example.com/",
+				Path:     "/test/",
+				Filename: "test.js",
+				Type:     "HTTP",
+				Source: &types.ScriptSource{
+					URL: "https://example.com/",
+				},
+			},
+			&TestRunSpec{
+				Script: K6Script{
+					HTTP: &K6HTTPScript{
+						URL: "https://example.com/",
+					},
+				},
+			},
+		},
+		{
+			"HTTP with unsupported scheme",
+			true,
+			nil,
+			&TestRunSpec{
+				Script: K6Script{
+					HTTP: &K6HTTPScript{
+						URL: "ftp://example.com/test.js",
+					},
+				},
+			},
+		},
+		{
+			"OCI",
+			false,
+			&types.Script{
+				Name:     "registry.example.com/tests/api:v1",
+				Path:     "/test/api/",
+				Filename: "main.js",
+				Type:     "OCI",
+				Source: &types.ScriptSource{
+					URL: "registry.example.com/tests/api:v1",
+				},
+			},
+			&TestRunSpec{
+				Script: K6Script{
+					OCI: &K6OCIScript{
+						Reference: "registry.example.com/tests/api:v1",
+						File:      "api/main.js",
+					},
+				},
+			},
+		},
+		{
+			"Inline",
+			false,
+			&types.Script{
+				Name:     "Inline",
+				Path:     "/test/",
+				Filename: "test.js",
+				Type:     "Inline",
+				Inline:   "export default function () {}",
+			},
+			&TestRunSpec{
+				Script: K6Script{
+					Inline: "export default function () {}",
+				},
+			},
+		},
+		{
+			"Bundle",
+			false,
+			&types.Script{
+				Name:     "Bundle",
+				Path:     "/test/api/",
+				Filename: "main.js",
+				Type:     "Bundle",
+				Bundle: []types.BundleItem{
+					{ConfigMap: "api-tests", Dir: "api"},
+					{Secret: "modules", Archive: "lib.tar.gz"},
+				},
+			},
+			&TestRunSpec{
+				Script: K6Script{
+					Bundle: &K6ScriptBundle{
+						File: "api/main.js",
+						Sources: []K6BundleSource{
+							{ConfigMap: "api-tests", Dir: "api"},
+							{Secret: "modules", Archive: "lib.tar.gz"},
+						},
+					},
+				},
+			},
+		},
+		{
+			"Bundle source with both ConfigMap and Secret",
+			true,
+			nil,
+			&TestRunSpec{
+				Script: K6Script{
+					Bundle: &K6ScriptBundle{
+						Sources: []K6BundleSource{{ConfigMap: "a", Secret: "b"}},
+					},
+				},
+			},
+		},
+		{
+			"Git with path outside of the repository",
+			true,
+			nil,
+			&TestRunSpec{
+				Script: K6Script{
+					Git: &K6GitScript{
+						URL:  "https://example.com/tests.git",
+						Path: "../etc",
+					},
+				},
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -314,3 +506,162 @@ func Test_Validate(t *testing.T) {
 		})
 	}
 }
+
+func Test_IsPauseChanged(t *testing.T) {
+	t.Parallel()
+
+	yes, no := true, false
+	tests := []struct {
+		name     string
+		paused   string
+		synced   *bool
+		expected bool
+	}{
+		{
+			name:     "not started yet",
+			paused:   "true",
+			synced:   nil,
+			expected: false,
+		},
+		{
+			name:     "default value at the start",
+			paused:   "true",
+			synced:   &yes,
+			expected: false,
+		},
+		{
+			name:     "resume requested",
+			paused:   "false",
+			synced:   &yes,
+			expected: true,
+		},
+		{
+			name:     "pause requested",
+			paused:   "true",
+			synced:   &no,
+			expected: true,
+		},
+		{
+			name:     "empty value is not paused",
+			paused:   "",
+			synced:   &no,
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			k6 := &TestRun{
+				Spec:   TestRunSpec{Paused: tt.paused},
+				Status: TestRunStatus{SyncedPaused: tt.synced},
+			}
+			if got := k6.IsPauseChanged(); got != tt.expected {
+				t.Errorf("IsPauseChanged() = %v, expected %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func Test_IsFetchingScript(t *testing.T) {
+	tests := []struct {
+		name     string
+		script   K6Script
+		expected bool
+	}{
+		{"ConfigMap", K6Script{ConfigMap: K6Configmap{Name: "test", File: "test.js"}}, false},
+		{"Inline", K6Script{Inline: "export default function () {}"}, false},
+		{"Git", K6Script{Git: &K6GitScript{URL: "https://example.com/tests.git"}}, true},
+		{"HTTP", K6Script{HTTP: &K6HTTPScript{URL: "https://example.com/test.js"}}, true},
+		{"OCI", K6Script{OCI: &K6OCIScript{Reference: "registry.example.com/tests/api:v1"}}, true},
+		{"Bundle", K6Script{Bundle: &K6ScriptBundle{}}, true},
+	}
+
+	for _, tt := range tests {
+		k6 := &TestRun{Spec: TestRunSpec{Script: tt.script}}
+		if got := k6.IsFetchingScript(); got != tt.expected {
+			t.Errorf("IsFetchingScript() of %s = %v, want %v", tt.name, got, tt.expected)
+		}
+	}
+}
+
+func Test_ScriptGrant_Permits(t *testing.T) {
+	grant := &ScriptGrant{
+		Spec: ScriptGrantSpec{
+			From: []ScriptGrantFrom{{Namespace: "team-a"}, {Namespace: "team-b"}},
+			To:   []ScriptGrantTo{{Kind: "ConfigMap", Name: "shared"}},
+		},
+	}
+
+	tests := []struct {
+		namespace, kind, name string
+		expected              bool
+	}{
+		{"team-a", "ConfigMap", "shared", true},
+		{"team-b", "ConfigMap", "shared", true},
+		{"team-c", "ConfigMap", "shared", false},
+		{"team-a", "ConfigMap", "other", false},
+		{"team-a", "Secret", "shared", false},
+	}
+
+	for _, tt := range tests {
+		if got := grant.Permits(tt.namespace, tt.kind, tt.name); got != tt.expected {
+			t.Errorf("Permits(%s, %s, %s) = %v, want %v", tt.namespace, tt.kind, tt.name, got, tt.expected)
+		}
+	}
+}
+
+func Test_TestSuiteSpec_Validate(t *testing.T) {
+	step := func(name string, dependsOn ...string) TestSuiteStep {
+		return TestSuiteStep{Name: name, DependsOn: dependsOn}
+	}
+
+	tests := []struct {
+		name        string
+		steps       []TestSuiteStep
+		expectedErr string
+	}{
+		{
+			name:  "DAG",
+			steps: []TestSuiteStep{step("smoke"), step("load-a", "smoke"), step("load-b", "smoke"), step("spike", "load-a", "load-b")},
+		},
+		{
+			name:        "duplicate step",
+			steps:       []TestSuiteStep{step("smoke"), step("smoke")},
+			expectedErr: "step smoke is defined more than once",
+		},
+		{
+			name:        "unknown dependency",
+			steps:       []TestSuiteStep{step("load", "smoke")},
+			expectedErr: "step load depends on unknown step smoke",
+		},
+		{
+			name:        "self-dependency",
+			steps:       []TestSuiteStep{step("smoke", "smoke")},
+			expectedErr: "steps have a dependency cycle: smoke -> smoke",
+		},
+		{
+			name:        "cycle",
+			steps:       []TestSuiteStep{step("a", "c"), step("b", "a"), step("c", "b")},
+			expectedErr: "steps have a dependency cycle: a -> c -> b -> a",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := &TestSuiteSpec{Steps: tt.steps}
+			err := spec.Validate()
+			if len(tt.expectedErr) == 0 {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.expectedErr {
+				t.Errorf("expected error %q, got %v", tt.expectedErr, err)
+			}
+		})
+	}
+}
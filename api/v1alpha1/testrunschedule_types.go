@@ -0,0 +1,151 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ScheduleLabel is the label with the name of the TestRunSchedule
+// on the TestRuns created by it.
+const ScheduleLabel = "k6.io/schedule"
+
+// ConcurrencyPolicy describes how to treat a new scheduled TestRun while
+// a previous one is still running.
+// +kubebuilder:validation:Enum=Allow;Forbid;Replace
+type ConcurrencyPolicy string
+
+const (
+	// AllowConcurrent allows TestRuns to run concurrently.
+	AllowConcurrent ConcurrencyPolicy = "Allow"
+	// ForbidConcurrent skips the new TestRun if the previous one is still running.
+	ForbidConcurrent ConcurrencyPolicy = "Forbid"
+	// ReplaceConcurrent deletes the running TestRun and creates the new one.
+	ReplaceConcurrent ConcurrencyPolicy = "Replace"
+)
+
+// TestRunObjectTemplate describes the TestRun to create.
+type TestRunObjectTemplate struct {
+	// Metadata of the TestRun.
+	// +optional
+	Metadata PodMetadata `json:"metadata,omitempty"`
+	// Spec of the TestRun.
+	Spec TestRunSpec `json:"spec"`
+}
+
+// TestRunScheduleSpec defines the desired state of TestRunSchedule
+type TestRunScheduleSpec struct {
+	// Schedule in the cron format, e.g. `0 2 * * *`, or one of the macros
+	// like `@hourly` and `@daily`.
+	// +kubebuilder:validation:MinLength=1
+	Schedule string `json:"schedule"`
+
+	// TimeZone of the schedule, e.g. `Europe/Berlin`. Defaults to UTC.
+	// +optional
+	TimeZone string `json:"timeZone,omitempty"`
+
+	// TestRunTemplate is the TestRun which is created on schedule.
+	TestRunTemplate TestRunObjectTemplate `json:"testRunTemplate"`
+
+	// ConcurrencyPolicy describes what to do if the previous TestRun is still
+	// running when the next one is due. Defaults to Allow.
+	// +kubebuilder:default=Allow
+	// +optional
+	ConcurrencyPolicy ConcurrencyPolicy `json:"concurrencyPolicy,omitempty"`
+
+	// Suspend stops creating new TestRuns. Running TestRuns are not affected.
+	// +optional
+	Suspend bool `json:"suspend,omitempty"`
+
+	// StartingDeadlineSeconds is for how long after the scheduled time a missed
+	// TestRun can still be created, e.g. after a restart of the operator.
+	// If omitted, a missed TestRun is always created.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	StartingDeadlineSeconds *int64 `json:"startingDeadlineSeconds,omitempty"`
+
+	// SuccessfulRunsHistoryLimit is the number of successful TestRuns to keep.
+	// +kubebuilder:default=3
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	SuccessfulRunsHistoryLimit *int32 `json:"successfulRunsHistoryLimit,omitempty"`
+
+	// FailedRunsHistoryLimit is the number of failed TestRuns to keep.
+	// +kubebuilder:default=1
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	FailedRunsHistoryLimit *int32 `json:"failedRunsHistoryLimit,omitempty"`
+}
+
+// TestRunScheduleStatus defines the observed state of TestRunSchedule
+type TestRunScheduleStatus struct {
+	// Active are the names of the TestRuns which are still running.
+	// +listType=atomic
+	// +optional
+	Active []string `json:"active,omitempty"`
+	// LastScheduleTime is the last time a TestRun was scheduled.
+	// +optional
+	LastScheduleTime *metav1.Time `json:"lastScheduleTime,omitempty"`
+	// LastTestRun is the name of the last finished TestRun.
+	// +optional
+	LastTestRun string `json:"lastTestRun,omitempty"`
+	// LastResult is the result of the last finished TestRun.
+	// +optional
+	LastResult TestRunResult `json:"lastResult,omitempty"`
+	// LastCompletionTime is the time when the last TestRun has finished.
+	// +optional
+	LastCompletionTime *metav1.Time `json:"lastCompletionTime,omitempty"`
+	// LastError is the message of the last error of scheduling, e.g. an invalid schedule.
+	// +optional
+	LastError string `json:"lastError,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Schedule",type="string",JSONPath=".spec.schedule"
+//+kubebuilder:printcolumn:name="Suspend",type="boolean",JSONPath=".spec.suspend"
+//+kubebuilder:printcolumn:name="Last Schedule",type="date",JSONPath=".status.lastScheduleTime"
+//+kubebuilder:printcolumn:name="Last Result",type="string",JSONPath=".status.lastResult"
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+//+kubebuilder:validation:XValidation:rule="self.metadata.name.size() <= 52",message="name must be no more than 52 characters"
+
+// TestRunSchedule creates TestRuns on a cron schedule.
+// As with CronJobs, the name is limited to 52 characters, so that
+// the names of TestRuns with the time suffix remain valid.
+type TestRunSchedule struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   TestRunScheduleSpec   `json:"spec,omitempty"`
+	Status TestRunScheduleStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// TestRunScheduleList contains a list of TestRunSchedule
+type TestRunScheduleList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []TestRunSchedule `json:"items"`
+}
+
+// TestRunName is the name of the TestRun scheduled at the given time.
+// It is unique for the schedule, since the cron format has minute precision,
+// and the same for repeated attempts to create it.
+func (s *TestRunSchedule) TestRunName(scheduled metav1.Time) string {
+	return fmt.Sprintf("%s-%d", s.Name, scheduled.Unix()/60)
+}
@@ -0,0 +1,85 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// TestRunTemplateKind is the kind of the namespaced template.
+	TestRunTemplateKind = "TestRunTemplate"
+	// ClusterTestRunTemplateKind is the kind of the cluster-scoped template.
+	ClusterTestRunTemplateKind = "ClusterTestRunTemplate"
+)
+
+// TestRunTemplateReference refers to a TestRunTemplate or a ClusterTestRunTemplate.
+type TestRunTemplateReference struct {
+	// Kind of the template.
+	// +kubebuilder:validation:Enum=TestRunTemplate;ClusterTestRunTemplate
+	// +kubebuilder:default=TestRunTemplate
+	// +optional
+	Kind string `json:"kind,omitempty"`
+	// Name of the template. A TestRunTemplate must be in the namespace of the TestRun.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// TestRunTemplate is a part of the TestRun spec shared by the TestRuns
+// of its namespace which reference it.
+type TestRunTemplate struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec is merged with the spec of the TestRun.
+	// A template cannot reference another template.
+	Spec TestRunSpec `json:"spec,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// TestRunTemplateList contains a list of TestRunTemplate
+type TestRunTemplateList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []TestRunTemplate `json:"items"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:resource:scope=Cluster
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// ClusterTestRunTemplate is a part of the TestRun spec shared by the TestRuns
+// of all namespaces which reference it.
+type ClusterTestRunTemplate struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec is merged with the spec of the TestRun.
+	// A template cannot reference another template.
+	Spec TestRunSpec `json:"spec,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// ClusterTestRunTemplateList contains a list of ClusterTestRunTemplate
+type ClusterTestRunTemplateList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ClusterTestRunTemplate `json:"items"`
+}
@@ -0,0 +1,244 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// SuiteLabel is the label with the name of the TestSuite
+	// on the TestRuns created by it.
+	SuiteLabel = "k6.io/suite"
+	// SuiteStepLabel is the label with the name of the step
+	// on the TestRuns created by a TestSuite.
+	SuiteStepLabel = "k6.io/suite-step"
+)
+
+// RunCondition describes when a step runs, depending on the outcome
+// of the steps it depends on.
+// +kubebuilder:validation:Enum=OnSuccess;OnFailure;Always
+type RunCondition string
+
+const (
+	// RunOnSuccess runs the step if all of its dependencies have succeeded.
+	RunOnSuccess RunCondition = "OnSuccess"
+	// RunOnFailure runs the step if any of its dependencies has failed.
+	RunOnFailure RunCondition = "OnFailure"
+	// RunAlways runs the step once all of its dependencies have finished,
+	// even if the suite is failing fast.
+	RunAlways RunCondition = "Always"
+)
+
+// TestSuiteSpec defines the desired state of TestSuite
+type TestSuiteSpec struct {
+	// Steps of the suite. Steps without dependencies start at once,
+	// the others once their dependencies have finished.
+	// +kubebuilder:validation:MinItems=1
+	// +listType=map
+	// +listMapKey=name
+	Steps []TestSuiteStep `json:"steps"`
+
+	// FailFast skips the steps which have not started yet and stops
+	// the running TestRuns as soon as a step fails. Steps which
+	// run Always are still run.
+	// +optional
+	FailFast bool `json:"failFast,omitempty"`
+}
+
+// TestSuiteStep is a TestRun of the suite.
+type TestSuiteStep struct {
+	// Name of the step, unique within the suite. It is a part of the name of the TestRun.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=63
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	Name string `json:"name"`
+
+	// DependsOn are the names of the steps which must finish before this one starts.
+	// +listType=set
+	// +optional
+	DependsOn []string `json:"dependsOn,omitempty"`
+
+	// RunCondition describes when the step runs, depending on the outcome
+	// of its dependencies. Defaults to OnSuccess.
+	// +kubebuilder:default=OnSuccess
+	// +optional
+	RunCondition RunCondition `json:"runCondition,omitempty"`
+
+	// TestRun is the TestRun to create for the step.
+	TestRun TestRunObjectTemplate `json:"testRun"`
+}
+
+// TestSuitePhase is the phase of a TestSuite or of one of its steps.
+// +kubebuilder:validation:Enum=Pending;Running;Succeeded;Failed;Skipped
+type TestSuitePhase string
+
+const (
+	// SuitePending means that the step waits for its dependencies.
+	SuitePending TestSuitePhase = "Pending"
+	// SuiteRunning means that the TestRuns are running.
+	SuiteRunning TestSuitePhase = "Running"
+	// SuiteSucceeded means that the TestRuns have passed.
+	SuiteSucceeded TestSuitePhase = "Succeeded"
+	// SuiteFailed means that a TestRun has failed, or that the suite is invalid.
+	SuiteFailed TestSuitePhase = "Failed"
+	// SuiteSkipped means that the step was not run because of its run
+	// condition or because the suite has failed fast.
+	SuiteSkipped TestSuitePhase = "Skipped"
+)
+
+// IsFinished checks if the phase is final.
+func (p TestSuitePhase) IsFinished() bool {
+	return p == SuiteSucceeded || p == SuiteFailed || p == SuiteSkipped
+}
+
+// TestSuiteStepStatus is the observed state of a step.
+type TestSuiteStepStatus struct {
+	// Name of the step.
+	Name string `json:"name"`
+	// Phase of the step.
+	Phase TestSuitePhase `json:"phase"`
+	// TestRun is the name of the TestRun of the step, once it is created.
+	// +optional
+	TestRun string `json:"testRun,omitempty"`
+	// Result of the TestRun, once it has finished.
+	// +optional
+	Result TestRunResult `json:"result,omitempty"`
+	// Message explains the phase, e.g. why the step was skipped.
+	// +optional
+	Message string `json:"message,omitempty"`
+	// StartTime is the time when the TestRun of the step was created.
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+	// CompletionTime is the time when the step has finished.
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+}
+
+// TestSuiteStatus defines the observed state of TestSuite
+type TestSuiteStatus struct {
+	// Phase of the suite. The suite has failed if any of its steps has failed.
+	// +optional
+	Phase TestSuitePhase `json:"phase,omitempty"`
+	// Steps are the states of the steps, in the order of the spec.
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	Steps []TestSuiteStepStatus `json:"steps,omitempty"`
+	// StartTime is the time when the suite has started.
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+	// CompletionTime is the time when all steps have finished.
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+	// LastError is the message of the error which has failed the suite,
+	// e.g. a dependency cycle.
+	// +optional
+	LastError string `json:"lastError,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase"
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// TestSuite runs TestRuns as steps, in sequence or in parallel,
+// according to the dependencies between them.
+type TestSuite struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   TestSuiteSpec   `json:"spec,omitempty"`
+	Status TestSuiteStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// TestSuiteList contains a list of TestSuite
+type TestSuiteList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []TestSuite `json:"items"`
+}
+
+// StepTestRunName is the name of the TestRun of the step.
+func (s *TestSuite) StepTestRunName(step string) string {
+	return s.Name + "-" + step
+}
+
+// StepStatus returns the status of the step with the given name, or nil.
+func (s *TestSuite) StepStatus(name string) *TestSuiteStepStatus {
+	for i := range s.Status.Steps {
+		if s.Status.Steps[i].Name == name {
+			return &s.Status.Steps[i]
+		}
+	}
+	return nil
+}
+
+// Validate checks that the steps have unique names and that their
+// dependencies exist and do not form a cycle.
+func (spec *TestSuiteSpec) Validate() error {
+	dependsOn := make(map[string][]string, len(spec.Steps))
+	for _, step := range spec.Steps {
+		if _, ok := dependsOn[step.Name]; ok {
+			return fmt.Errorf("step %s is defined more than once", step.Name)
+		}
+		dependsOn[step.Name] = step.DependsOn
+	}
+
+	for _, step := range spec.Steps {
+		for _, dep := range step.DependsOn {
+			if _, ok := dependsOn[dep]; !ok {
+				return fmt.Errorf("step %s depends on unknown step %s", step.Name, dep)
+			}
+		}
+	}
+
+	// Depth-first search for a cycle: a step which is reached again
+	// while its dependencies are being visited is on a cycle.
+	const (
+		visiting = 1
+		visited  = 2
+	)
+	state := map[string]int{}
+	var visit func(name string, path []string) error
+	visit = func(name string, path []string) error {
+		switch state[name] {
+		case visiting:
+			return fmt.Errorf("steps have a dependency cycle: %s", strings.Join(append(path, name), " -> "))
+		case visited:
+			return nil
+		}
+		state[name] = visiting
+		for _, dep := range dependsOn[name] {
+			if err := visit(dep, append(path, name)); err != nil {
+				return err
+			}
+		}
+		state[name] = visited
+		return nil
+	}
+	for _, step := range spec.Steps {
+		if err := visit(step.Name, nil); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -18,14 +18,20 @@ package main
 
 import (
 	"flag"
+	"fmt"
 	"os"
 	"strings"
+	// time zones of TestRunSchedules must resolve in images without tzdata
+	_ "time/tzdata"
 
 	controllers "github.com/grafana/k6-operator/internal/controller"
+	webhookv1alpha1 "github.com/grafana/k6-operator/internal/webhook/v1alpha1"
 	"github.com/grafana/k6-operator/pkg/plz"
+	"github.com/grafana/k6-operator/pkg/testrun"
 
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/kubernetes"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	_ "k8s.io/client-go/plugin/pkg/client/auth/gcp"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -55,11 +61,22 @@ func main() {
 	var metricsAddr string
 	var healthAddr string
 	var enableLeaderElection bool
+	var leaderElectionNamespace string
+	var enableWebhooks bool
+	var runnerAPITransport string
 	flag.StringVar(&metricsAddr, "metrics-bind-address", ":8080", "The address the metric endpoint binds to.")
 	flag.StringVar(&healthAddr, "health-probe-bind-address", ":8081", "The address the health endpoint binds to.")
 	flag.BoolVar(&enableLeaderElection, "leader-elect", false,
 		"Enable leader election for controller manager. "+
 			"Enabling this will ensure there is only one active controller manager.")
+	flag.StringVar(&leaderElectionNamespace, "leader-election-namespace", "",
+		"Namespace of the leader election lease. It is required when running outside of the cluster.")
+	flag.BoolVar(&enableWebhooks, "enable-webhooks", false,
+		"Enable validating admission webhooks for TestRun and PrivateLoadZone. "+
+			"Webhook server requires a TLS certificate in /tmp/k8s-webhook-server/serving-certs.")
+	flag.StringVar(&runnerAPITransport, "runner-api-transport", "direct",
+		"How k6 REST API of the runners is reached: \"direct\" via the pod network or "+
+			"\"proxy\" via the pod proxy of Kubernetes API server.")
 
 	opts := zap.Options{
 		Development: true,
@@ -79,6 +96,7 @@ func main() {
 		}),
 		LeaderElection:             enableLeaderElection,
 		LeaderElectionID:           "fcdfce80.io",
+		LeaderElectionNamespace:    leaderElectionNamespace,
 		LeaderElectionResourceLock: "leases",
 		HealthProbeBindAddress:     healthAddr,
 	}
@@ -110,11 +128,36 @@ func main() {
 	_ = mgr.AddHealthzCheck("health", healthz.Ping)
 	_ = mgr.AddReadyzCheck("ready", healthz.Ping)
 
+	// All clients use the config of the manager, which is loaded either from
+	// the service account or from a kubeconfig, e.g. with --kubeconfig flag.
+	clientset, err := kubernetes.NewForConfig(mgr.GetConfig())
+	if err != nil {
+		setupLog.Error(err, "unable to create clientset")
+		os.Exit(1)
+	}
+
+	var runnerTransport testrun.Transport
+	switch runnerAPITransport {
+	case "direct":
+		runnerTransport = testrun.DirectTransport{}
+	case "proxy":
+		if runnerTransport, err = testrun.NewProxyTransport(mgr.GetConfig()); err != nil {
+			setupLog.Error(err, "unable to create the proxy transport for runners")
+			os.Exit(1)
+		}
+	default:
+		setupLog.Error(fmt.Errorf("unknown runner API transport %q", runnerAPITransport), "unable to start manager")
+		os.Exit(1)
+	}
+
 	if err = (&controllers.TestRunReconciler{
-		Client:   mgr.GetClient(),
-		Log:      ctrl.Log.WithName("controllers").WithName("TestRun"),
-		Scheme:   mgr.GetScheme(),
-		Recorder: mgr.GetEventRecorder("testrun-controller"),
+		Client:          mgr.GetClient(),
+		Log:             ctrl.Log.WithName("controllers").WithName("TestRun"),
+		Scheme:          mgr.GetScheme(),
+		Recorder:        mgr.GetEventRecorder("testrun-controller"),
+		Clientset:       clientset,
+		RunnerTransport: runnerTransport,
+		APIReader:       mgr.GetAPIReader(),
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "TestRun")
 		os.Exit(1)
@@ -127,6 +170,35 @@ func main() {
 		setupLog.Error(err, "unable to create controller", "controller", "PrivateLoadZone")
 		os.Exit(1)
 	}
+	if err = (&controllers.TestRunScheduleReconciler{
+		Client:   mgr.GetClient(),
+		Log:      ctrl.Log.WithName("controllers").WithName("TestRunSchedule"),
+		Scheme:   mgr.GetScheme(),
+		Recorder: mgr.GetEventRecorder("testrunschedule-controller"),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "TestRunSchedule")
+		os.Exit(1)
+	}
+	if err = (&controllers.TestSuiteReconciler{
+		Client:   mgr.GetClient(),
+		Log:      ctrl.Log.WithName("controllers").WithName("TestSuite"),
+		Scheme:   mgr.GetScheme(),
+		Recorder: mgr.GetEventRecorder("testsuite-controller"),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "TestSuite")
+		os.Exit(1)
+	}
+
+	if enableWebhooks {
+		if err = webhookv1alpha1.SetupTestRunWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "TestRun")
+			os.Exit(1)
+		}
+		if err = webhookv1alpha1.SetupPrivateLoadZoneWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "PrivateLoadZone")
+			os.Exit(1)
+		}
+	}
 
 	plz.SetScheme(scheme)
 
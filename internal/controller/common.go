@@ -7,6 +7,9 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"regexp"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
 
@@ -14,12 +17,12 @@ import (
 
 	"github.com/grafana/k6-operator/api/v1alpha1"
 	"github.com/grafana/k6-operator/pkg/cloud"
+	"github.com/grafana/k6-operator/pkg/resources/jobs"
 	"github.com/grafana/k6-operator/pkg/testrun"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/client-go/kubernetes"
-	"k8s.io/client-go/rest"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -30,7 +33,7 @@ const (
 // It may take some time to retrieve inspect output so indicate with boolean if it's ready
 // and use returnErr only for errors that require a change of behaviour. All other errors
 // should just be logged.
-func inspectTestRun(ctx context.Context, log logr.Logger, k6 *v1alpha1.TestRun, c client.Client) (
+func inspectTestRun(ctx context.Context, log logr.Logger, k6 *v1alpha1.TestRun, c client.Client, clientset kubernetes.Interface) (
 	inspectOutput cloud.InspectOutput, ready bool, returnErr error) {
 	var (
 		listOpts = &client.ListOptions{
@@ -56,37 +59,50 @@ func inspectTestRun(ctx context.Context, log logr.Logger, k6 *v1alpha1.TestRun,
 	}
 
 	// there should be only 1 initializer pod
-	if podList.Items[0].Status.Phase == corev1.PodFailed {
-		returnErr = errors.New("initalizer job has failed")
+	pod := &podList.Items[0]
+	if pod.Status.Phase == corev1.PodFailed {
+		returnErr = newInitializerError(pod)
 		log.Error(returnErr, "error:")
 		return
 	}
-	if podList.Items[0].Status.Phase != corev1.PodSucceeded {
+	if pod.Status.Phase != corev1.PodSucceeded {
 		log.Info("Waiting for initializing pod to finish")
 		return
 	}
 
+	// The commit of the git script is recorded for traceability, and
+	// so that the runners check out the same commit.
+	if commit := gitContainerCommit(pod); len(commit) > 0 {
+		k6.GetStatus().ScriptCommit = commit
+	}
+
+	// The initializer writes the output to the termination message. It is
+	// truncated by kubelet if it's too long, and then we read the logs instead.
+	if terminated := k6ContainerTermination(pod); terminated != nil && len(terminated.Message) > 0 {
+		if err = json.Unmarshal([]byte(terminated.Message), &inspectOutput); err == nil {
+			ready = true
+			return
+		}
+		log.Info(fmt.Sprintf("Termination message of the initializer is not a valid output: %v; reading the logs", err))
+		inspectOutput = cloud.InspectOutput{}
+	}
+
 	// Here we need to get the output of the pod
 	// pods/log is not currently supported by controller-runtime client and it is officially
 	// recommended to use REST client instead:
 	// https://github.com/kubernetes-sigs/controller-runtime/issues/1229
+	// The clientset is created from the same config as the manager, so this works
+	// outside of the cluster as well.
 
 	// TODO: if the below errors repeat several times, it'd be a real error case scenario.
 	// How likely is it? Should we track frequency of these errors here?
-	config, err := rest.InClusterConfig()
-	if err != nil {
-		log.Error(err, "unable to fetch in-cluster REST config")
-		returnErr = err
+	if clientset == nil {
+		returnErr = errors.New("no clientset to read the logs of the initializer pod")
+		log.Error(returnErr, "error:")
 		return
 	}
 
-	clientset, err := kubernetes.NewForConfig(config)
-	if err != nil {
-		log.Error(err, "unable to get access to clientset")
-		returnErr = err
-		return
-	}
-	req := clientset.CoreV1().Pods(k6.NamespacedName().Namespace).GetLogs(podList.Items[0].Name, &corev1.PodLogOptions{
+	req := clientset.CoreV1().Pods(k6.NamespacedName().Namespace).GetLogs(pod.Name, &corev1.PodLogOptions{
 		Container: "k6",
 	})
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second*60)
@@ -117,6 +133,115 @@ func inspectTestRun(ctx context.Context, log logr.Logger, k6 *v1alpha1.TestRun,
 	return
 }
 
+// InitializerError is the failure of the initializer pod, with the error
+// messages of k6 when they are available.
+type InitializerError struct {
+	// Reason is ScriptError when k6 reported an error, e.g. in the script.
+	Reason   string
+	Message  string
+	ExitCode int32
+}
+
+func (e *InitializerError) Error() string {
+	return e.Message
+}
+
+// k6ErrorMessage matches the message of a k6 log line in logfmt.
+var k6ErrorMessage = regexp.MustCompile(`msg="((?:[^"\\]|\\.)*)"`)
+
+func newInitializerError(pod *corev1.Pod) *InitializerError {
+	e := &InitializerError{
+		Reason:  "InitializerFailed",
+		Message: "initializer job has failed",
+	}
+
+	if sourceErr := scriptSourceError(pod); sourceErr != nil {
+		return sourceErr
+	}
+
+	terminated := k6ContainerTermination(pod)
+	if terminated == nil {
+		return e
+	}
+
+	e.ExitCode = terminated.ExitCode
+	if msg := strings.TrimSpace(terminated.Message); len(msg) > 0 {
+		e.Message = msg
+	}
+
+	var messages []string
+	for _, m := range k6ErrorMessage.FindAllStringSubmatch(e.Message, -1) {
+		if unquoted, err := strconv.Unquote(`"` + m[1] + `"`); err == nil {
+			messages = append(messages, unquoted)
+		} else {
+			messages = append(messages, m[1])
+		}
+	}
+	// Exit code 127 means that k6 is missing, which is an error of the image, not of the script.
+	if len(messages) > 0 && e.ExitCode != 127 {
+		e.Reason = "ScriptError"
+		e.Message = strings.Join(messages, "; ")
+	}
+
+	return e
+}
+
+// scriptSourceError describes the failure of the init container which
+// fetches the script, if it has failed.
+func scriptSourceError(pod *corev1.Pod) *InitializerError {
+	for _, status := range pod.Status.InitContainerStatuses {
+		terminated := status.State.Terminated
+		if terminated == nil || terminated.ExitCode == 0 {
+			continue
+		}
+
+		e := &InitializerError{
+			Message:  "failed to fetch the script",
+			ExitCode: terminated.ExitCode,
+		}
+		switch {
+		case status.Name == jobs.GitContainerName:
+			e.Reason = "GitCloneFailed"
+		case status.Name == jobs.DownloadContainerName && terminated.ExitCode == jobs.ChecksumMismatchExitCode:
+			e.Reason = "ScriptChecksumMismatch"
+		case status.Name == jobs.DownloadContainerName:
+			e.Reason = "ScriptDownloadFailed"
+		case status.Name == jobs.UnpackContainerName:
+			e.Reason = "ScriptUnpackFailed"
+		default:
+			continue
+		}
+		if msg := strings.TrimSpace(terminated.Message); len(msg) > 0 {
+			e.Message = msg
+		}
+		return e
+	}
+	return nil
+}
+
+func k6ContainerTermination(pod *corev1.Pod) *corev1.ContainerStateTerminated {
+	return containerTermination(pod.Status.ContainerStatuses, "k6")
+}
+
+// gitContainerCommit returns the commit checked out by the git init container
+// of the pod, if there is one.
+func gitContainerCommit(pod *corev1.Pod) string {
+	terminated := containerTermination(pod.Status.InitContainerStatuses, jobs.GitContainerName)
+	if terminated == nil || terminated.ExitCode != 0 {
+		return ""
+	}
+	return strings.TrimSpace(terminated.Message)
+}
+
+func containerTermination(statuses []corev1.ContainerStatus, name string) *corev1.ContainerStateTerminated {
+	for _, status := range statuses {
+		if status.Name == name {
+			return status.State.Terminated
+		}
+	}
+	return nil
+}
+
 func getEnvVar(vars []corev1.EnvVar, name string) string {
 	for _, v := range vars {
 		if v.Name == name {
@@ -126,44 +251,70 @@ func getEnvVar(vars []corev1.EnvVar, name string) string {
 	return ""
 }
 
-func (r *TestRunReconciler) hostnames(ctx context.Context, log logr.Logger, abortOnUnready bool, opts *client.ListOptions) ([]string, error) {
-	var (
-		hostnames []string
-		err       error
-	)
+func (r *TestRunReconciler) transport() testrun.Transport {
+	if r.RunnerTransport == nil {
+		return testrun.DirectTransport{}
+	}
+	return r.RunnerTransport
+}
 
-	sl := &corev1.ServiceList{}
+// isPodReady checks the Ready condition of the pod. The readiness probe
+// of the runners calls k6 REST API, so a ready runner can accept requests.
+func isPodReady(pod *corev1.Pod) bool {
+	for _, c := range pod.Status.Conditions {
+		if c.Type == corev1.PodReady {
+			return c.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
 
-	if err = r.List(ctx, sl, opts); err != nil {
-		log.Error(err, "Could not list services")
+// runners returns the runner pods of the test run which can serve k6 REST API:
+// those which are still running or, with readyOnly, those which are ready.
+func (r *TestRunReconciler) runners(ctx context.Context, log logr.Logger, k6 *v1alpha1.TestRun, readyOnly bool) ([]testrun.Runner, error) {
+	pl := &corev1.PodList{}
+	if err := r.List(ctx, pl, k6.ListOptions()); err != nil {
+		log.Error(err, "Could not list pods")
 		return nil, err
 	}
 
-	for _, service := range sl.Items {
-		log.Info(fmt.Sprintf("Checking service %s", service.Name))
-		if isServiceReady(log, &service) {
-			log.Info(fmt.Sprintf("%v service is ready", service.Name))
-			hostnames = append(hostnames, service.Spec.ClusterIP)
-		} else {
-			err = fmt.Errorf("%v service is not ready", service.Name)
-			log.Info(err.Error())
-			if abortOnUnready {
-				return nil, err
-			}
+	runners := make([]testrun.Runner, 0, len(pl.Items))
+	for _, pod := range pl.Items {
+		if pod.Status.Phase != corev1.PodRunning || !pod.DeletionTimestamp.IsZero() {
+			continue
+		}
+		if readyOnly && !isPodReady(&pod) {
+			log.Info(fmt.Sprintf("%v pod is not ready", pod.Name))
+			continue
+		}
+
+		name := pod.Labels["job-name"]
+		if len(name) == 0 {
+			name = pod.Name
 		}
+		runners = append(runners, testrun.Runner{
+			Name:      name,
+			Namespace: pod.Namespace,
+			Pod:       pod.Name,
+			IP:        pod.Status.PodIP,
+		})
 	}
 
-	return hostnames, nil
+	slices.SortFunc(runners, func(a, b testrun.Runner) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	return runners, nil
 }
 
 // runSetup returns an outcome of HTTP calls, as well as
 // a retry bool showing whether operation should be retried
 // despite the error.
 // (for example, if there was a networking glitch).
-func runSetup(ctx context.Context, hostnames []string, log logr.Logger) (error, bool) {
+func runSetup(ctx context.Context, t testrun.Transport, runners []testrun.Runner, log logr.Logger) (error, bool) {
 	log.Info("Invoking setup() on the first runner")
 
-	setupData, err := testrun.RunSetup(ctx, hostnames[0])
+	setupData, err := testrun.RunSetup(ctx, t, runners[0])
 	if err != nil {
 		// Is there a better way to get this error? Where is NDE...
 		if strings.Contains(err.Error(), "Error executing") {
@@ -177,7 +328,7 @@ func runSetup(ctx context.Context, hostnames []string, log logr.Logger) (error,
 
 	log.Info("Sending setup data to the runners")
 
-	if err = testrun.SetSetupData(ctx, hostnames, setupData); err != nil {
+	if err = testrun.SetSetupData(ctx, t, runners, setupData); err != nil {
 		// we cannot retry this operation without preserving setupData somewhere
 		return err, false
 	}
@@ -185,10 +336,12 @@ func runSetup(ctx context.Context, hostnames []string, log logr.Logger) (error,
 	return nil, false
 }
 
-func runTeardown(ctx context.Context, hostnames []string, log logr.Logger) {
-	log.Info("Invoking teardown() on the first responsive runner")
+func runTeardown(ctx context.Context, t testrun.Transport, runners []testrun.Runner, log logr.Logger) error {
+	log.Info("Invoking teardown() on the first ready runner")
 
-	if err := testrun.RunTeardown(ctx, hostnames); err != nil {
+	err := testrun.RunTeardown(ctx, t, runners)
+	if err != nil {
 		log.Error(err, "Failed to invoke teardown()")
 	}
+	return err
 }
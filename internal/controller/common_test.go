@@ -0,0 +1,164 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/grafana/k6-operator/pkg/resources/jobs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func initializerPod(phase corev1.PodPhase, exitCode int32, message string) *corev1.Pod {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-initializer-abcde",
+			Namespace: "default",
+			Labels: map[string]string{
+				"app":      "k6",
+				"k6_cr":    "test",
+				"job-name": "test-initializer",
+			},
+		},
+		Status: corev1.PodStatus{Phase: phase},
+	}
+	if phase == corev1.PodSucceeded || phase == corev1.PodFailed {
+		pod.Status.ContainerStatuses = []corev1.ContainerStatus{{
+			Name: "k6",
+			State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{
+				ExitCode: exitCode,
+				Message:  message,
+			}},
+		}}
+	}
+	return pod
+}
+
+// withSourceContainer adds the status of the init container fetching the script to the initializer pod.
+func withSourceContainer(pod *corev1.Pod, name string, exitCode int32, message string) *corev1.Pod {
+	pod.Status.InitContainerStatuses = []corev1.ContainerStatus{{
+		Name: name,
+		State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{
+			ExitCode: exitCode,
+			Message:  message,
+		}},
+	}}
+	return pod
+}
+
+func Test_inspectTestRun(t *testing.T) {
+	k6 := testRunInStage("initialization", metav1.Now().Time, nil)
+
+	t.Run("output in the termination message", func(t *testing.T) {
+		r := newTestReconciler(t, initializerPod(corev1.PodSucceeded, 0, `{"maxVUs":10,"totalDuration":"1m30s"}`))
+
+		output, ready, err := inspectTestRun(context.Background(), logr.Discard(), k6, r.Client, nil)
+		require.NoError(t, err)
+		assert.True(t, ready)
+		assert.Equal(t, uint64(10), output.MaxVUs)
+	})
+
+	t.Run("commit of the git script", func(t *testing.T) {
+		k6 := k6.DeepCopy()
+		r := newTestReconciler(t, withSourceContainer(
+			initializerPod(corev1.PodSucceeded, 0, `{"maxVUs":10,"totalDuration":"1m30s"}`), jobs.GitContainerName, 0, "0123456789abcdef\n"))
+
+		_, ready, err := inspectTestRun(context.Background(), logr.Discard(), k6, r.Client, nil)
+		require.NoError(t, err)
+		assert.True(t, ready)
+		assert.Equal(t, "0123456789abcdef", k6.GetStatus().ScriptCommit)
+	})
+
+	t.Run("truncated termination message", func(t *testing.T) {
+		pod := initializerPod(corev1.PodSucceeded, 0, `{"maxVUs":10,"totalDu`)
+		r := newTestReconciler(t, pod)
+
+		_, ready, err := inspectTestRun(context.Background(), logr.Discard(), k6, r.Client, nil)
+		assert.False(t, ready)
+		assert.Error(t, err)
+
+		// The logs of the fake clientset are not a valid inspect output,
+		// but they are read without an in-cluster config.
+		_, ready, err = inspectTestRun(context.Background(), logr.Discard(), k6, r.Client, fake.NewClientset(pod))
+		assert.True(t, ready)
+		var syntaxErr *json.SyntaxError
+		assert.ErrorAs(t, err, &syntaxErr)
+	})
+
+	t.Run("initializer is running", func(t *testing.T) {
+		r := newTestReconciler(t, initializerPod(corev1.PodRunning, 0, ""))
+
+		_, ready, err := inspectTestRun(context.Background(), logr.Discard(), k6, r.Client, nil)
+		assert.NoError(t, err)
+		assert.False(t, ready)
+	})
+
+	t.Run("initializer has failed", func(t *testing.T) {
+		r := newTestReconciler(t, initializerPod(corev1.PodFailed, 1,
+			`time="2026-10-17T10:00:00Z" level=error msg="SyntaxError: file:///test/test.js: Unexpected token (3:2)" hint="script exception"`))
+
+		_, ready, err := inspectTestRun(context.Background(), logr.Discard(), k6, r.Client, nil)
+		assert.False(t, ready)
+
+		var initializerErr *InitializerError
+		require.ErrorAs(t, err, &initializerErr)
+		assert.Equal(t, "ScriptError", initializerErr.Reason)
+		assert.Equal(t, "SyntaxError: file:///test/test.js: Unexpected token (3:2)", initializerErr.Message)
+	})
+}
+
+func Test_newInitializerError(t *testing.T) {
+	tests := []struct {
+		name     string
+		pod      *corev1.Pod
+		expected InitializerError
+	}{
+		{
+			name:     "no container status",
+			pod:      initializerPod(corev1.PodPending, 0, ""),
+			expected: InitializerError{Reason: "InitializerFailed", Message: "initializer job has failed"},
+		},
+		{
+			name: "several errors of k6",
+			pod: initializerPod(corev1.PodFailed, 1, "level=error msg=\"first \\\"error\\\"\"\n"+
+				"level=error msg=\"second error\"\n"),
+			expected: InitializerError{Reason: "ScriptError", Message: `first "error"; second error`, ExitCode: 1},
+		},
+		{
+			name:     "k6 is missing",
+			pod:      initializerPod(corev1.PodFailed, 127, `level=error msg="k6 executable not found in PATH; initializer image must contain k6"`),
+			expected: InitializerError{Reason: "InitializerFailed", Message: `level=error msg="k6 executable not found in PATH; initializer image must contain k6"`, ExitCode: 127},
+		},
+		{
+			name:     "git clone has failed",
+			pod:      withSourceContainer(initializerPod(corev1.PodFailed, 0, ""), jobs.GitContainerName, 128, "fatal: couldn't find remote ref v2\n"),
+			expected: InitializerError{Reason: "GitCloneFailed", Message: "fatal: couldn't find remote ref v2", ExitCode: 128},
+		},
+		{
+			name:     "download has failed",
+			pod:      withSourceContainer(initializerPod(corev1.PodFailed, 0, ""), jobs.DownloadContainerName, 22, "curl: (22) The requested URL returned error: 404\n"),
+			expected: InitializerError{Reason: "ScriptDownloadFailed", Message: "curl: (22) The requested URL returned error: 404", ExitCode: 22},
+		},
+		{
+			name:     "checksum mismatch",
+			pod:      withSourceContainer(initializerPod(corev1.PodFailed, 0, ""), jobs.DownloadContainerName, jobs.ChecksumMismatchExitCode, ""),
+			expected: InitializerError{Reason: "ScriptChecksumMismatch", Message: "failed to fetch the script", ExitCode: jobs.ChecksumMismatchExitCode},
+		},
+		{
+			name:     "unexpected output",
+			pod:      initializerPod(corev1.PodFailed, 2, "exec format error\n"),
+			expected: InitializerError{Reason: "InitializerFailed", Message: "exec format error", ExitCode: 2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, *newInitializerError(tt.pod))
+		})
+	}
+}
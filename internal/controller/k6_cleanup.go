@@ -0,0 +1,205 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/grafana/k6-operator/api/v1alpha1"
+	"github.com/grafana/k6-operator/pkg/resources/jobs"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// finishedAt returns the time when the test run has reached the finished or error stage.
+func finishedAt(k6 *v1alpha1.TestRun) time.Time {
+	if t := k6.GetStatus().StageTransitionTime; t != nil {
+		return t.Time
+	}
+	return k6.GetCreationTimestamp().Time
+}
+
+// ApplyCleanupPolicy cleans up a finished TestRun according to .spec.cleanupPolicy.
+// If the TTL has not passed yet, the TestRun is requeued for the time left.
+func ApplyCleanupPolicy(ctx context.Context, log logr.Logger, k6 *v1alpha1.TestRun, r *TestRunReconciler, now time.Time) (ctrl.Result, error) {
+	policy := k6.GetCleanupPolicy()
+
+	if policy.KeepFailed && k6.IsFailed() {
+		return ctrl.Result{}, nil
+	}
+
+	if policy.Type == v1alpha1.CleanupKeepLastN {
+		return ctrl.Result{}, keepLastTestRuns(ctx, log, k6, r)
+	}
+
+	if policy.TTL != nil {
+		if left := finishedAt(k6).Add(policy.TTL.Duration).Sub(now); left > 0 {
+			return ctrl.Result{RequeueAfter: left}, nil
+		}
+	}
+
+	switch policy.Type {
+	case v1alpha1.CleanupDeleteResources:
+		return ctrl.Result{}, deleteResources(ctx, log, k6, r)
+
+	case v1alpha1.CleanupDeleteTestRun:
+		log.Info("TTL has passed: deleting the TestRun")
+		if err := r.Delete(ctx, k6); err != nil && !k8sErrors.IsNotFound(err) {
+			log.Error(err, "Failed to delete the TestRun")
+			return ctrl.Result{}, err
+		}
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// deleteResources deletes all Jobs and Services of the TestRun,
+// together with their Pods, but keeps the TestRun itself.
+func deleteResources(ctx context.Context, log logr.Logger, k6 *v1alpha1.TestRun, r *TestRunReconciler) error {
+	jl := &batchv1.JobList{}
+	if err := r.List(ctx, jl, k6.ListOptions()); err != nil {
+		log.Error(err, "Could not list jobs")
+		return err
+	}
+
+	sl := &corev1.ServiceList{}
+	if err := r.List(ctx, sl, k6.ListOptions()); err != nil {
+		log.Error(err, "Could not list services")
+		return err
+	}
+
+	if len(jl.Items) == 0 && len(sl.Items) == 0 {
+		return nil
+	}
+
+	log.Info(fmt.Sprintf("TTL has passed: deleting %d jobs and %d services", len(jl.Items), len(sl.Items)))
+
+	propagationPolicy := client.PropagationPolicy(metav1.DeletePropagationBackground)
+	for _, job := range jl.Items {
+		if err := r.Delete(ctx, &job, propagationPolicy); err != nil && !k8sErrors.IsNotFound(err) {
+			log.Error(err, fmt.Sprintf("Failed to delete job %s", job.Name))
+			return err
+		}
+	}
+
+	for _, service := range sl.Items {
+		if err := r.Delete(ctx, &service); err != nil && !k8sErrors.IsNotFound(err) {
+			log.Error(err, fmt.Sprintf("Failed to delete service %s", service.Name))
+			return err
+		}
+	}
+
+	if k6.IsReusingArchive() {
+		claim := jobs.NewArchiveClaim(k6)
+		if err := r.Delete(ctx, claim); err != nil && !k8sErrors.IsNotFound(err) {
+			log.Error(err, fmt.Sprintf("Failed to delete volume claim %s", claim.Name))
+			return err
+		}
+	}
+
+	if k6.IsReferencingScript() {
+		return deleteScriptCopy(ctx, log, k6, r)
+	}
+
+	return nil
+}
+
+// deleteScriptCopy deletes the copy of the script from another namespace,
+// if it is owned by the TestRun.
+func deleteScriptCopy(ctx context.Context, log logr.Logger, k6 *v1alpha1.TestRun, r *TestRunReconciler) error {
+	scriptCopy := &corev1.ConfigMap{}
+	key := types.NamespacedName{Namespace: k6.Namespace, Name: jobs.ScriptCopyName(k6)}
+	if err := r.Get(ctx, key, scriptCopy); err != nil {
+		if k8sErrors.IsNotFound(err) {
+			return nil
+		}
+		log.Error(err, fmt.Sprintf("Could not get ConfigMap %s", key.Name))
+		return err
+	}
+
+	if !metav1.IsControlledBy(scriptCopy, k6) {
+		return nil
+	}
+
+	if err := r.Delete(ctx, scriptCopy); err != nil && !k8sErrors.IsNotFound(err) {
+		log.Error(err, fmt.Sprintf("Failed to delete ConfigMap %s", scriptCopy.Name))
+		return err
+	}
+	return nil
+}
+
+// keepLastTestRuns deletes finished TestRuns with the same value of the group
+// label as this TestRun, except for the last KeepLast of them.
+func keepLastTestRuns(ctx context.Context, log logr.Logger, k6 *v1alpha1.TestRun, r *TestRunReconciler) error {
+	policy := k6.GetCleanupPolicy()
+
+	group, ok := k6.GetLabels()[policy.GroupLabel]
+	if !ok {
+		log.Info(fmt.Sprintf("TestRun has no %s label: skipping the cleanup", policy.GroupLabel))
+		return nil
+	}
+
+	list := &v1alpha1.TestRunList{}
+	if err := r.List(ctx, list, client.InNamespace(k6.Namespace), client.MatchingLabels{policy.GroupLabel: group}); err != nil {
+		log.Error(err, "Could not list test runs")
+		return err
+	}
+
+	for _, testRun := range testRunsToDelete(k6, list.Items, policy) {
+		log.Info(fmt.Sprintf("Deleting TestRun %s: only the last %d are kept", testRun.Name, keepLast(policy)))
+		if err := r.Delete(ctx, &testRun); err != nil && !k8sErrors.IsNotFound(err) {
+			log.Error(err, fmt.Sprintf("Failed to delete TestRun %s", testRun.Name))
+			return err
+		}
+	}
+
+	return nil
+}
+
+// keepLast is the number of finished TestRuns to keep. At least one is
+// always kept, even if the webhook has not validated the policy.
+func keepLast(policy *v1alpha1.CleanupPolicy) int {
+	return max(int(policy.KeepLast), 1)
+}
+
+// testRunsToDelete selects finished TestRuns beyond the last KeepLast of them.
+// Failed test runs are neither deleted nor counted with KeepFailed. Only the
+// TestRuns which have the KeepLastN policy themselves are counted, and the
+// TestRun applying the policy is never deleted by it.
+func testRunsToDelete(k6 *v1alpha1.TestRun, testRuns []v1alpha1.TestRun, policy *v1alpha1.CleanupPolicy) []v1alpha1.TestRun {
+	var finished []v1alpha1.TestRun
+	for _, testRun := range testRuns {
+		switch testRun.GetStatus().Stage {
+		case "finished", "error":
+		default:
+			continue
+		}
+		if own := testRun.GetCleanupPolicy(); own == nil || own.Type != v1alpha1.CleanupKeepLastN {
+			continue
+		}
+		if policy.KeepFailed && testRun.IsFailed() {
+			continue
+		}
+		finished = append(finished, testRun)
+	}
+
+	if len(finished) <= keepLast(policy) {
+		return nil
+	}
+
+	// the most recently finished first
+	slices.SortFunc(finished, func(a, b v1alpha1.TestRun) int {
+		return finishedAt(&b).Compare(finishedAt(&a))
+	})
+
+	return slices.DeleteFunc(finished[keepLast(policy):], func(testRun v1alpha1.TestRun) bool {
+		return testRun.Name == k6.Name
+	})
+}
@@ -0,0 +1,96 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/grafana/k6-operator/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+func finishedTestRun(name string, stage v1alpha1.Stage, finished time.Time) v1alpha1.TestRun {
+	return v1alpha1.TestRun{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Status: v1alpha1.TestRunStatus{
+			Stage:               stage,
+			StageTransitionTime: &metav1.Time{Time: finished},
+		},
+	}
+}
+
+func Test_testRunsToDelete(t *testing.T) {
+	now := time.Now()
+	testRuns := []v1alpha1.TestRun{
+		finishedTestRun("oldest", "finished", now.Add(-3*time.Hour)),
+		finishedTestRun("failed", "error", now.Add(-2*time.Hour)),
+		finishedTestRun("newest", "finished", now.Add(-time.Hour)),
+		finishedTestRun("running", "started", now),
+		finishedTestRun("other-policy", "finished", now.Add(-4*time.Hour)),
+	}
+	for i := range testRuns[:4] {
+		testRuns[i].Spec.CleanupPolicy = &v1alpha1.CleanupPolicy{Type: v1alpha1.CleanupKeepLastN}
+	}
+	testRuns[4].Spec.CleanupPolicy = &v1alpha1.CleanupPolicy{Type: v1alpha1.CleanupDeleteResources}
+	k6 := &testRuns[2]
+
+	names := func(testRuns []v1alpha1.TestRun) (names []string) {
+		for _, testRun := range testRuns {
+			names = append(names, testRun.Name)
+		}
+		return
+	}
+
+	policy := &v1alpha1.CleanupPolicy{Type: v1alpha1.CleanupKeepLastN, KeepLast: 1}
+	assert.Equal(t, []string{"failed", "oldest"}, names(testRunsToDelete(k6, testRuns, policy)))
+
+	policy.KeepFailed = true
+	assert.Equal(t, []string{"oldest"}, names(testRunsToDelete(k6, testRuns, policy)))
+
+	policy.KeepLast = 3
+	assert.Empty(t, testRunsToDelete(k6, testRuns, policy))
+
+	// An omitted keepLast keeps one TestRun, and the TestRun applying
+	// the policy is kept even if it is not among the last ones.
+	policy = &v1alpha1.CleanupPolicy{Type: v1alpha1.CleanupKeepLastN}
+	assert.Equal(t, []string{"failed"}, names(testRunsToDelete(&testRuns[0], testRuns, policy)))
+}
+
+func Test_ApplyCleanupPolicy_DeleteResources(t *testing.T) {
+	now := time.Now()
+	k6 := finishedTestRun("test", "finished", now.Add(-time.Minute))
+	k6.Spec.CleanupPolicy = &v1alpha1.CleanupPolicy{
+		Type: v1alpha1.CleanupDeleteResources,
+		TTL:  &metav1.Duration{Duration: 5 * time.Minute},
+	}
+	service := &corev1.Service{ObjectMeta: metav1.ObjectMeta{
+		Name:      "test-service-1",
+		Namespace: "default",
+		Labels:    map[string]string{"app": "k6", "k6_cr": "test", "runner": "true"},
+	}}
+	r := newTestReconciler(t, k6.DeepCopy(), runnerJob("test-1", 0), service)
+
+	res, err := ApplyCleanupPolicy(context.Background(), logr.Discard(), &k6, r, now)
+	require.NoError(t, err)
+	assert.Equal(t, ctrl.Result{RequeueAfter: 4 * time.Minute}, res)
+
+	res, err = ApplyCleanupPolicy(context.Background(), logr.Discard(), &k6, r, now.Add(5*time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, ctrl.Result{}, res)
+
+	jl := &batchv1.JobList{}
+	require.NoError(t, r.List(context.Background(), jl, k6.ListOptions()))
+	assert.Empty(t, jl.Items)
+
+	sl := &corev1.ServiceList{}
+	require.NoError(t, r.List(context.Background(), sl, k6.ListOptions()))
+	assert.Empty(t, sl.Items)
+
+	require.NoError(t, r.Get(context.Background(), k6.NamespacedName(), &v1alpha1.TestRun{}))
+}
@@ -40,11 +40,14 @@ func CreateJobs(ctx context.Context, log logr.Logger, k6 *v1alpha1.TestRun, r *T
 	log.Info("Creating test jobs")
 
 	if res, recheck, err := createJobSpecs(ctx, log, k6, r, tokenInfo); err != nil {
+		r.Recorder.Eventf(k6, nil, corev1.EventTypeWarning, "JobCreationFailed", "CreateRunners",
+			"failed to create runner jobs: %v", err)
+
 		if v1alpha1.IsTrue(k6, v1alpha1.CloudTestRun) {
 			events := cloud.ErrorEvent(cloud.K6OperatorStartError).
 				WithDetail(fmt.Sprintf("Failed to create runner jobs: %v", err)).
 				WithAbort()
-			cloud.SendTestRunEvents(cloudClient, k6.TestRunID(), log, events)
+			cloud.SendTestRunEvents(cloudClient, k6.NamespacedName(), k6.TestRunID(), log, events)
 		}
 
 		return res, err
@@ -0,0 +1,55 @@
+package controllers
+
+import (
+	"fmt"
+
+	"github.com/grafana/k6-operator/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+)
+
+// stageEvents defines the reason and the note of the event emitted when
+// the TestRun enters a stage. The error stage is handled separately.
+var stageEvents = map[v1alpha1.Stage]struct{ reason, note string }{
+	"initialization": {"Initializing", "initializer job was created to inspect the script"},
+	"initialized":    {"Initialized", "script was inspected successfully"},
+	"created":        {"RunnersCreated", "%d runner jobs were created"},
+	"started":        {"Started", "test was started on %d runners"},
+	"stopped":        {"Stopped", "runners have stopped execution"},
+	"finished":       {"Finished", "test run has finished"},
+}
+
+// emitStageEvent emits an event about the stage of the TestRun, if
+// it has changed since the previous version of the TestRun.
+func (r *TestRunReconciler) emitStageEvent(previous, current *v1alpha1.TestRun) {
+	stage := current.GetStatus().Stage
+	if previous.GetStatus().Stage == stage {
+		return
+	}
+
+	if stage == "error" {
+		reason := "Failed"
+		if v1alpha1.IsTrue(current, v1alpha1.TestRunFailed) {
+			reason = meta.FindStatusCondition(current.GetStatus().Conditions, v1alpha1.TestRunFailed).Reason
+		}
+		r.Recorder.Eventf(current, nil, corev1.EventTypeWarning, reason, "ChangeStage", "test run has failed: %s", current.GetStatus().LastError)
+		return
+	}
+
+	e, ok := stageEvents[stage]
+	if !ok {
+		return
+	}
+
+	note := e.note
+	switch stage {
+	case "created", "started":
+		note = fmt.Sprintf(e.note, current.GetSpec().Parallelism)
+	case "finished":
+		if result := current.GetStatus().Result; len(result) > 0 {
+			note = fmt.Sprintf("%s with result %s", e.note, result)
+		}
+	}
+
+	r.Recorder.Eventf(current, nil, corev1.EventTypeNormal, e.reason, "ChangeStage", "%s", note)
+}
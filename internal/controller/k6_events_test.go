@@ -0,0 +1,70 @@
+package controllers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/grafana/k6-operator/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/events"
+)
+
+func Test_emitStageEvent(t *testing.T) {
+	failed := testRunInStage("error", time.Now(), nil)
+	failed.Status.LastError = "setup function failed: boom"
+	v1alpha1.SetCondition(failed, v1alpha1.TestRunFailed, metav1.ConditionTrue, "SetupFailed", failed.Status.LastError)
+
+	finished := testRunInStage("finished", time.Now(), nil)
+	finished.Status.Result = v1alpha1.ResultPassed
+
+	created := testRunInStage("created", time.Now(), nil)
+	created.Spec.Parallelism = 3
+
+	tests := []struct {
+		name     string
+		previous *v1alpha1.TestRun
+		current  *v1alpha1.TestRun
+		expected []string
+	}{
+		{
+			name:     "stage is not changed",
+			previous: testRunInStage("started", time.Now(), nil),
+			current:  testRunInStage("started", time.Now(), nil),
+		},
+		{
+			name:     "runners are created",
+			previous: testRunInStage("initialized", time.Now(), nil),
+			current:  created,
+			expected: []string{"Normal RunnersCreated 3 runner jobs were created"},
+		},
+		{
+			name:     "test run has finished",
+			previous: testRunInStage("stopped", time.Now(), nil),
+			current:  finished,
+			expected: []string{"Normal Finished test run has finished with result Passed"},
+		},
+		{
+			name:     "test run has failed",
+			previous: testRunInStage("created", time.Now(), nil),
+			current:  failed,
+			expected: []string{"Warning SetupFailed test run has failed: setup function failed: boom"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := events.NewFakeRecorder(10)
+			r := &TestRunReconciler{Recorder: recorder}
+
+			r.emitStageEvent(tt.previous, tt.current)
+			close(recorder.Events)
+
+			var emitted []string
+			for e := range recorder.Events {
+				emitted = append(emitted, e)
+			}
+			assert.Equal(t, tt.expected, emitted)
+		})
+	}
+}
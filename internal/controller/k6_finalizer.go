@@ -0,0 +1,139 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/grafana/k6-operator/api/v1alpha1"
+	"github.com/grafana/k6-operator/pkg/cloud"
+	corev1 "k8s.io/api/core/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const (
+	testRunFinalizer = "testruns.k6.io/finalizer"
+
+	// finalizeTimeout bounds for how long the finalizer can hold back
+	// the deletion of a TestRun, e.g. when runners do not react to the stop
+	// call or k6 Cloud cannot be reached.
+	finalizeTimeout = 2 * time.Minute
+)
+
+// needsFinalizer is true while the TestRun may have something to clean up
+// on deletion: runners to stop or a cloud test run to finalize.
+func needsFinalizer(k6 *v1alpha1.TestRun) bool {
+	if !k6.DeletionTimestamp.IsZero() {
+		return false
+	}
+	switch k6.GetStatus().Stage {
+	case "finished", "error":
+		return false
+	}
+	return true
+}
+
+// AddFinalizer adds the TestRun finalizer if it is not present yet.
+// It returns true if the TestRun was updated.
+func AddFinalizer(ctx context.Context, log logr.Logger, k6 *v1alpha1.TestRun, r *TestRunReconciler) (bool, error) {
+	if !controllerutil.AddFinalizer(k6, testRunFinalizer) {
+		return false, nil
+	}
+	if err := r.Update(ctx, k6); err != nil {
+		log.Error(err, "Could not add the finalizer")
+		return false, err
+	}
+	return true, nil
+}
+
+// RemoveFinalizer removes the TestRun finalizer, if it is present.
+func RemoveFinalizer(ctx context.Context, log logr.Logger, k6 *v1alpha1.TestRun, r *TestRunReconciler) error {
+	if !controllerutil.RemoveFinalizer(k6, testRunFinalizer) {
+		return nil
+	}
+	if err := r.Update(ctx, k6); err != nil {
+		log.Error(err, "Could not remove the finalizer")
+		return err
+	}
+	return nil
+}
+
+// FinalizeTestRun is called when a TestRun with the finalizer is deleted.
+// Running runners are stopped gracefully with the stop job, and the cloud
+// test run, if any, is aborted. The finalizer is removed once that is done,
+// or once finalizeTimeout has passed since the deletion.
+func FinalizeTestRun(ctx context.Context, log logr.Logger, k6 *v1alpha1.TestRun, r *TestRunReconciler, now time.Time) (ctrl.Result, error) {
+	if !controllerutil.ContainsFinalizer(k6, testRunFinalizer) {
+		return ctrl.Result{}, nil
+	}
+
+	deadline := k6.DeletionTimestamp.Add(finalizeTimeout)
+	if now.After(deadline) {
+		log.Info(fmt.Sprintf("TestRun could not be finalized within %s: removing the finalizer", finalizeTimeout))
+		r.Recorder.Eventf(k6, nil, corev1.EventTypeWarning, "FinalizeTimedOut", "Finalizing",
+			"test run could not be finalized within %s", finalizeTimeout)
+		return ctrl.Result{}, RemoveFinalizer(ctx, log, k6, r)
+	}
+
+	if k6.GetStatus().Stage == "started" {
+		if err := createStopJob(ctx, log, k6, r); err != nil {
+			return ctrl.Result{RequeueAfter: time.Second}, nil
+		}
+		// Lingering runners do not exit after the stop call, so it is
+		// enough for them to stop execution.
+		if !RunnersExited(ctx, log, k6, r) && !(k6.IsLingering() && StoppedJobs(ctx, log, k6, r)) {
+			log.Info("Waiting for the runners to stop before deleting the TestRun")
+			return ctrl.Result{RequeueAfter: time.Second * 5}, nil
+		}
+	}
+
+	if isCloudTestRun(k6) && len(k6.TestRunID()) > 0 && !v1alpha1.IsTrue(k6, v1alpha1.CloudTestRunFinalized) {
+		if err := r.finalizeCloudTestRun(ctx, log, k6); err != nil {
+			r.Recorder.Eventf(k6, nil, corev1.EventTypeWarning, "CloudFinalizeFailed", "Finalizing",
+				"failed to abort cloud test run %s: %v", k6.TestRunID(), err)
+			return ctrl.Result{RequeueAfter: time.Second * 5}, nil
+		}
+	}
+
+	r.Recorder.Eventf(k6, nil, corev1.EventTypeNormal, "Finalized", "Finalizing",
+		"test run was finalized before deletion")
+
+	return ctrl.Result{}, RemoveFinalizer(ctx, log, k6, r)
+}
+
+// finalizeCloudTestRun aborts the k6 Cloud test run of a deleted TestRun.
+// A PLZ test run is aborted with an event, while a test run with cloud
+// output is finalized directly, as aborted unless it has already stopped.
+func (r *TestRunReconciler) finalizeCloudTestRun(ctx context.Context, log logr.Logger, k6 *v1alpha1.TestRun) error {
+	cloudClient, found, err := r.createClient(ctx, k6, log)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return fmt.Errorf("token `%s` is not found", k6.GetSpec().Token)
+	}
+
+	if v1alpha1.IsTrue(k6, v1alpha1.CloudPLZTestRun) {
+		events := cloud.Events{cloud.AbortEvent(cloud.OriginUser)}
+		cloud.SendTestRunEvents(cloudClient, k6.NamespacedName(), k6.TestRunID(), log, events.WithDetail("TestRun was deleted"))
+		return nil
+	}
+
+	if !v1alpha1.IsTrue(k6, v1alpha1.CloudTestRunCreated) {
+		return nil
+	}
+
+	finish := cloud.AbortTestRun
+	if k6.GetStatus().Stage == "stopped" && !v1alpha1.IsTrue(k6, v1alpha1.TestRunStoppedByUser) {
+		finish = cloud.FinishTestRun
+	}
+	if err := finish(cloudClient, k6.NamespacedName(), k6.TestRunID()); err != nil {
+		log.Error(err, "Failed to finalize the test run with cloud output")
+		return err
+	}
+
+	log.Info(fmt.Sprintf("Cloud test run %s was finalized on deletion", k6.TestRunID()))
+	return nil
+}
@@ -0,0 +1,122 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/grafana/k6-operator/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	batchv1 "k8s.io/api/batch/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+func deletedTestRun(stage v1alpha1.Stage, deletedAt time.Time) *v1alpha1.TestRun {
+	k6 := testRunInStage(stage, deletedAt, nil)
+	k6.Spec.Parallelism = 1
+	k6.DeletionTimestamp = &metav1.Time{Time: deletedAt}
+	k6.Finalizers = []string{testRunFinalizer}
+	return k6
+}
+
+func Test_needsFinalizer(t *testing.T) {
+	now := time.Now()
+
+	assert.True(t, needsFinalizer(testRunInStage("", now, nil)))
+	assert.True(t, needsFinalizer(testRunInStage("started", now, nil)))
+	assert.False(t, needsFinalizer(testRunInStage("finished", now, nil)))
+	assert.False(t, needsFinalizer(testRunInStage("error", now, nil)))
+	assert.False(t, needsFinalizer(deletedTestRun("started", now)))
+}
+
+func Test_FinalizeTestRun(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name             string
+		k6               *v1alpha1.TestRun
+		jobs             []client.Object
+		expectedFinalize bool
+		expectedStopJob  bool
+	}{
+		{
+			name:             "test run has not started yet",
+			k6:               deletedTestRun("created", now),
+			jobs:             []client.Object{runnerJob("test-1", 1)},
+			expectedFinalize: true,
+		},
+		{
+			name:            "runners are still active",
+			k6:              deletedTestRun("started", now),
+			jobs:            []client.Object{runnerJob("test-1", 1)},
+			expectedStopJob: true,
+		},
+		{
+			name:             "runners have exited",
+			k6:               deletedTestRun("started", now),
+			jobs:             []client.Object{runnerJob("test-1", 0)},
+			expectedFinalize: true,
+			expectedStopJob:  true,
+		},
+		{
+			name:             "finalization has timed out",
+			k6:               deletedTestRun("started", now.Add(-finalizeTimeout-time.Second)),
+			jobs:             []client.Object{runnerJob("test-1", 1)},
+			expectedFinalize: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			r := newTestReconciler(t, append(tt.jobs, tt.k6)...)
+
+			k6 := &v1alpha1.TestRun{}
+			require.NoError(t, r.Get(ctx, tt.k6.NamespacedName(), k6))
+
+			res, err := FinalizeTestRun(ctx, logr.Discard(), k6, r, now)
+			require.NoError(t, err)
+
+			// The fake client deletes the object once its finalizers are removed.
+			err = r.Get(ctx, tt.k6.NamespacedName(), &v1alpha1.TestRun{})
+			if tt.expectedFinalize {
+				assert.True(t, k8sErrors.IsNotFound(err))
+				assert.Zero(t, res.RequeueAfter)
+			} else {
+				require.NoError(t, err)
+				assert.NotZero(t, res.RequeueAfter)
+			}
+
+			stopJob := &batchv1.Job{}
+			err = r.Get(ctx, client.ObjectKey{Namespace: "default", Name: "test-stopper"}, stopJob)
+			assert.Equal(t, tt.expectedStopJob, err == nil)
+		})
+	}
+}
+
+func Test_AddFinalizer(t *testing.T) {
+	ctx := context.Background()
+	k6 := testRunInStage("", time.Now(), nil)
+	r := newTestReconciler(t, k6)
+
+	added, err := AddFinalizer(ctx, logr.Discard(), k6, r)
+	require.NoError(t, err)
+	assert.True(t, added)
+
+	added, err = AddFinalizer(ctx, logr.Discard(), k6, r)
+	require.NoError(t, err)
+	assert.False(t, added)
+
+	stored := &v1alpha1.TestRun{}
+	require.NoError(t, r.Get(ctx, k6.NamespacedName(), stored))
+	assert.True(t, controllerutil.ContainsFinalizer(stored, testRunFinalizer))
+
+	require.NoError(t, RemoveFinalizer(ctx, logr.Discard(), stored, r))
+	require.NoError(t, r.Get(ctx, k6.NamespacedName(), stored))
+	assert.False(t, controllerutil.ContainsFinalizer(stored, testRunFinalizer))
+}
@@ -36,7 +36,8 @@ func FinishJobs(ctx context.Context, log logr.Logger, k6 *v1alpha1.TestRun, r *T
 		return
 	}
 
-	// TODO: We should distinguish between Suceeded/Failed/Unknown
+	// Here it matters only whether jobs have finished:
+	// their results are determined later, with SetResult.
 	var (
 		finished, failed int32
 	)
@@ -58,7 +59,7 @@ func FinishJobs(ctx context.Context, log logr.Logger, k6 *v1alpha1.TestRun, r *T
 		events := cloud.ErrorEvent(cloud.K6OperatorRunnerError).
 			WithDetail(msg).
 			WithAbort()
-		cloud.SendTestRunEvents(cloudClient, k6.TestRunID(), log, events)
+		cloud.SendTestRunEvents(cloudClient, k6.NamespacedName(), k6.TestRunID(), log, events)
 	}
 
 	if finished < k6.GetSpec().Parallelism {
@@ -2,7 +2,9 @@ package controllers
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/grafana/k6-operator/pkg/types"
@@ -13,6 +15,8 @@ import (
 	"github.com/grafana/k6-operator/pkg/cloud"
 	"github.com/grafana/k6-operator/pkg/resources/jobs"
 	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
 )
@@ -38,6 +42,12 @@ func InitializeJobs(ctx context.Context, log logr.Logger, k6 *v1alpha1.TestRun,
 		return res, err
 	}
 
+	if k6.IsReusingArchive() {
+		if err = createArchiveClaim(ctx, log, k6, r); err != nil {
+			return res, err
+		}
+	}
+
 	if err = r.Create(ctx, initializer); err != nil {
 		log.Error(err, "Failed to launch k6 test initializer")
 		return res, err
@@ -46,6 +56,23 @@ func InitializeJobs(ctx context.Context, log logr.Logger, k6 *v1alpha1.TestRun,
 	return res, nil
 }
 
+// createArchiveClaim creates the PersistentVolumeClaim for k6 archive,
+// which is written by the initializer and run by the runners.
+func createArchiveClaim(ctx context.Context, log logr.Logger, k6 *v1alpha1.TestRun, r *TestRunReconciler) error {
+	claim := jobs.NewArchiveClaim(k6)
+
+	if err := ctrl.SetControllerReference(k6, claim, r.Scheme); err != nil {
+		log.Error(err, "Failed to set controller reference for the archive volume claim")
+		return err
+	}
+
+	if err := r.Create(ctx, claim); err != nil && !k8sErrors.IsAlreadyExists(err) {
+		log.Error(err, "Failed to create the archive volume claim")
+		return err
+	}
+	return nil
+}
+
 func RunValidations(ctx context.Context, log logr.Logger, k6 *v1alpha1.TestRun, r *TestRunReconciler, cloudClient *cloudapi.Client) (
 	res ctrl.Result, ready bool, err error,
 ) {
@@ -55,8 +82,18 @@ func RunValidations(ctx context.Context, log logr.Logger, k6 *v1alpha1.TestRun,
 	// validation has already happened, so error here can be ignored
 	cli, _ := types.ParseCLI(k6.GetSpec().Argv())
 
-	inspectOutput, inspectReady, err := inspectTestRun(ctx, log, k6, r.Client)
+	inspectOutput, inspectReady, err := inspectTestRun(ctx, log, k6, r.Client, r.Clientset)
 	if err != nil {
+		initializerFailures.WithLabelValues(k6.NamespacedName().Namespace, k6.NamespacedName().Name).Inc()
+
+		reason := "InitializerFailed"
+		var initializerErr *InitializerError
+		if errors.As(err, &initializerErr) {
+			reason = initializerErr.Reason
+		}
+		msg := fmt.Sprintf("failed to inspect the test script: %v", err)
+		r.Recorder.Eventf(k6, nil, corev1.EventTypeWarning, reason, "InspectScript", "%s", msg)
+
 		// Cloud output test run is not created yet at this point, so sending
 		// events is possible only for PLZ test run.
 		if v1alpha1.IsTrue(k6, v1alpha1.CloudPLZTestRun) {
@@ -64,10 +101,17 @@ func RunValidations(ctx context.Context, log logr.Logger, k6 *v1alpha1.TestRun,
 			events := cloud.ErrorEvent(cloud.K6OperatorStartError).
 				WithDetail(fmt.Sprintf("Failed to inspect the test script: %v", err)).
 				WithAbort()
-			cloud.SendTestRunEvents(cloudClient, k6.TestRunID(), log, events)
+			cloud.SendTestRunEvents(cloudClient, k6.NamespacedName(), k6.TestRunID(), log, events)
 		} else {
 			// if there is any error, we have to reflect it on the TestRun manifest
+			if reason == "ScriptError" {
+				k6.GetStatus().Result = v1alpha1.ResultScriptError
+			}
+			v1alpha1.SetCondition(k6, v1alpha1.TestRunFailed, metav1.ConditionTrue, reason, msg)
+			v1alpha1.UpdateCondition(k6, v1alpha1.TestRunSucceeded, metav1.ConditionFalse)
+
 			k6.GetStatus().Stage = "error"
+			k6.GetStatus().LastError = msg
 			if _, err := r.UpdateStatus(ctx, k6, log); err != nil {
 				return ctrl.Result{}, ready, err
 			}
@@ -94,6 +138,8 @@ func RunValidations(ctx context.Context, log logr.Logger, k6 *v1alpha1.TestRun,
 			"parallelism", k6.GetSpec().Parallelism)
 
 		k6.GetStatus().Stage = "error"
+		k6.GetStatus().LastError = fmt.Sprintf("parallelism %d is larger than the maximum of %d VUs in the script",
+			k6.GetSpec().Parallelism, inspectOutput.MaxVUs)
 
 		if _, err := r.UpdateStatus(ctx, k6, log); err != nil {
 			return ctrl.Result{}, ready, err
@@ -116,6 +162,21 @@ func RunValidations(ctx context.Context, log logr.Logger, k6 *v1alpha1.TestRun,
 		v1alpha1.UpdateCondition(k6, v1alpha1.CloudTestRun, metav1.ConditionFalse)
 	}
 
+	if k6.IsEvaluatingThresholds() {
+		thresholds := thresholdStatuses(inspectOutput.Thresholds)
+
+		// Runners are started with --no-thresholds, so a threshold which
+		// the operator cannot evaluate would be silently dropped.
+		if unsupported := unsupportedThresholds(thresholds); len(unsupported) > 0 {
+			msg := fmt.Sprintf("thresholds cannot be evaluated by the operator, as k6 REST API does not expose their values: %s",
+				strings.Join(unsupported, ", "))
+			res, err = r.failTestRun(ctx, log, k6, cloudClient, v1alpha1.ResultScriptError, "UnsupportedThresholds", msg)
+			return res, ready, err
+		}
+
+		k6.GetStatus().Thresholds = thresholds
+	}
+
 	if _, err := r.UpdateStatus(ctx, k6, log); err != nil {
 		return ctrl.Result{}, ready, err
 	}
@@ -130,7 +191,7 @@ func RunValidations(ctx context.Context, log logr.Logger, k6 *v1alpha1.TestRun,
 func SetupCloudTest(ctx context.Context, log logr.Logger, k6 *v1alpha1.TestRun, r *TestRunReconciler, cloudClient *cloudapi.Client) (res ctrl.Result, err error) {
 	res = ctrl.Result{RequeueAfter: time.Second * 5}
 
-	inspectOutput, inspectReady, err := inspectTestRun(ctx, log, k6, r.Client)
+	inspectOutput, inspectReady, err := inspectTestRun(ctx, log, k6, r.Client, r.Clientset)
 	if err != nil {
 		// This *shouldn't* fail since it was already done once. Don't requeue.
 		// Alternatively: store inspect options in TestRun Status? Get rid off reading logs?
@@ -156,7 +217,7 @@ func SetupCloudTest(ctx context.Context, log logr.Logger, k6 *v1alpha1.TestRun,
 			inspectOutput.SetTestName(script.Filename)
 		}
 
-		if testRunData, err := cloud.CreateTestRun(inspectOutput, k6.GetSpec().Parallelism, cloudClient, log); err != nil {
+		if testRunData, err := cloud.CreateTestRun(inspectOutput, k6.GetSpec().Parallelism, cloudClient, k6.NamespacedName(), log); err != nil {
 			log.Error(err, "Failed to create a new cloud test run.")
 			return res, nil
 		} else {
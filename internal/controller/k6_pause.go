@@ -0,0 +1,49 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/grafana/k6-operator/api/v1alpha1"
+	"github.com/grafana/k6-operator/pkg/testrun"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// PauseJobs synchronizes runners of a started test run with .spec.paused:
+// they are paused or resumed via k6 REST API.
+func PauseJobs(ctx context.Context, log logr.Logger, k6 *v1alpha1.TestRun, r *TestRunReconciler) (res ctrl.Result, err error) {
+	if len(k6.GetStatus().TestRunID) > 0 {
+		log = log.WithValues("testRunId", k6.GetStatus().TestRunID)
+	}
+
+	paused := k6.IsPaused()
+
+	// Runners which have already finished are skipped.
+	runners, err := r.runners(ctx, log, k6, true)
+	if err != nil {
+		return ctrl.Result{RequeueAfter: time.Second}, nil
+	}
+
+	log.Info(fmt.Sprintf("Setting paused = %v on %d runners", paused, len(runners)))
+
+	if err = testrun.SetPaused(ctx, r.transport(), runners, paused); err != nil {
+		log.Error(err, "Failed to change paused state of the runners")
+		return ctrl.Result{RequeueAfter: time.Second * 5}, nil
+	}
+
+	k6.GetStatus().ObservedPaused = &paused
+	k6.GetStatus().SyncedPaused = &paused
+	if paused {
+		v1alpha1.UpdateCondition(k6, v1alpha1.TestRunPaused, metav1.ConditionTrue)
+	} else {
+		v1alpha1.UpdateCondition(k6, v1alpha1.TestRunPaused, metav1.ConditionFalse)
+	}
+
+	if _, err = r.UpdateStatus(ctx, k6, log); err != nil {
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{RequeueAfter: time.Second}, nil
+}
@@ -0,0 +1,212 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"github.com/grafana/k6-operator/api/v1alpha1"
+	"github.com/grafana/k6-operator/pkg/testrun"
+	k6api "go.k6.io/k6/v2/api/v1"
+	"go.k6.io/k6/v2/errext/exitcodes"
+	"go.k6.io/k6/v2/lib"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// runnerResult maps exit code of k6 to the result of the runner.
+func runnerResult(exitCode int32) v1alpha1.TestRunResult {
+	if exitCode == 0 {
+		return v1alpha1.ResultPassed
+	}
+	if exitCode < 0 || exitCode > 255 {
+		return v1alpha1.ResultRunnerFailed
+	}
+
+	switch exitcodes.ExitCode(exitCode) {
+	case exitcodes.ThresholdsHaveFailed, exitcodes.CloudTestRunFailed:
+		return v1alpha1.ResultThresholdsFailed
+
+	case exitcodes.ScriptStoppedFromRESTAPI, exitcodes.ExternalAbort, exitcodes.ScriptAborted:
+		return v1alpha1.ResultAborted
+
+	case exitcodes.ScriptException, exitcodes.InvalidConfig, exitcodes.MarkedAsFailed,
+		exitcodes.SetupTimeout, exitcodes.TeardownTimeout:
+		return v1alpha1.ResultScriptError
+	}
+
+	// Anything else, e.g. 137 on OOM kill, is a problem with the runner itself.
+	return v1alpha1.ResultRunnerFailed
+}
+
+// resultPriority defines which result of a runner determines the result
+// of the whole test run: the first one found in this list wins.
+var resultPriority = []v1alpha1.TestRunResult{
+	v1alpha1.ResultRunnerFailed,
+	v1alpha1.ResultScriptError,
+	v1alpha1.ResultAborted,
+	v1alpha1.ResultThresholdsFailed,
+	v1alpha1.ResultPassed,
+}
+
+// overallResult combines results of the runners. There must be exactly
+// parallelism results, otherwise some runners have never finished.
+func overallResult(runners []v1alpha1.RunnerResult, parallelism int32) v1alpha1.TestRunResult {
+	if len(runners) < int(parallelism) {
+		return v1alpha1.ResultRunnerFailed
+	}
+
+	for _, result := range resultPriority {
+		if slices.ContainsFunc(runners, func(r v1alpha1.RunnerResult) bool { return r.Result == result }) {
+			return result
+		}
+	}
+	return v1alpha1.ResultPassed
+}
+
+// runnerResults derives results from the terminated k6 containers of runner pods.
+func runnerResults(pods []corev1.Pod) []v1alpha1.RunnerResult {
+	var results []v1alpha1.RunnerResult
+
+	for _, pod := range pods {
+		name := pod.Labels["job-name"]
+		if len(name) == 0 {
+			name = pod.Name
+		}
+
+		result := v1alpha1.RunnerResult{
+			Name:   name,
+			Result: v1alpha1.ResultRunnerFailed,
+		}
+
+		for _, cs := range pod.Status.ContainerStatuses {
+			if cs.Name != "k6" || cs.State.Terminated == nil {
+				continue
+			}
+			exitCode := cs.State.Terminated.ExitCode
+			result.ExitCode = &exitCode
+			result.Result = runnerResult(exitCode)
+		}
+
+		results = append(results, result)
+	}
+
+	slices.SortFunc(results, func(a, b v1alpha1.RunnerResult) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	return results
+}
+
+// SetResult determines the result of the test run from the runner pods,
+// and sets TestRunSucceeded and TestRunFailed conditions accordingly.
+// The status is not updated here.
+func SetResult(ctx context.Context, log logr.Logger, k6 *v1alpha1.TestRun, r *TestRunReconciler) error {
+	pl := &corev1.PodList{}
+	if err := r.List(ctx, pl, k6.ListOptions()); err != nil {
+		log.Error(err, "Could not list pods")
+		return err
+	}
+
+	setResult(log, k6, r, runnerResults(pl.Items))
+	return nil
+}
+
+// lingeringRunnerResult maps the execution status of a lingering runner
+// to its result. Such a runner has no exit code until it is terminated.
+func lingeringRunnerResult(status k6api.Status) v1alpha1.TestRunResult {
+	switch {
+	case status.Status == lib.ExecutionStatusInterrupted:
+		return v1alpha1.ResultAborted
+	case status.Status == lib.ExecutionStatusMarkedAsFailed:
+		return v1alpha1.ResultScriptError
+	case status.Tainted:
+		return v1alpha1.ResultThresholdsFailed
+	}
+	return v1alpha1.ResultPassed
+}
+
+// SetLingeringResult determines the result of the test run from the REST API
+// of the lingering runners. It must be called before the runners are deleted.
+// The status is not updated here.
+func SetLingeringResult(ctx context.Context, log logr.Logger, k6 *v1alpha1.TestRun, r *TestRunReconciler) error {
+	runners, err := r.runners(ctx, log, k6, false)
+	if err != nil {
+		return err
+	}
+
+	results := make([]v1alpha1.RunnerResult, 0, len(runners))
+	for _, runner := range runners {
+		status, err := testrun.GetStatus(ctx, r.transport(), runner)
+		if err != nil {
+			log.Error(err, fmt.Sprintf("Could not get status from %s", runner.Name))
+			return err
+		}
+
+		results = append(results, v1alpha1.RunnerResult{
+			Name:   runner.Name,
+			Result: lingeringRunnerResult(status),
+		})
+	}
+
+	setResult(log, k6, r, results)
+	return nil
+}
+
+func setResult(log logr.Logger, k6 *v1alpha1.TestRun, r *TestRunReconciler, runners []v1alpha1.RunnerResult) {
+	result := overallResult(runners, k6.GetSpec().Parallelism)
+
+	// Runners do not know about thresholds evaluated by the operator, and
+	// they were stopped by the operator if such a threshold has aborted the test.
+	thresholdsFailed := v1alpha1.IsFalse(k6, v1alpha1.ThresholdsPassed)
+	if thresholdsFailed && (result == v1alpha1.ResultPassed || result == v1alpha1.ResultAborted) {
+		result = v1alpha1.ResultThresholdsFailed
+	}
+
+	// Runners of a stopped test run might have been deleted
+	// before they could exit on their own.
+	if v1alpha1.IsTrue(k6, v1alpha1.TestRunStoppedByUser) || v1alpha1.IsTrue(k6, v1alpha1.CloudTestRunAborted) {
+		result = v1alpha1.ResultAborted
+	}
+
+	log.Info(fmt.Sprintf("Result of the test run is %s", result))
+
+	k6.GetStatus().Result = result
+	k6.GetStatus().RunnerResults = runners
+
+	if result == v1alpha1.ResultPassed {
+		v1alpha1.UpdateCondition(k6, v1alpha1.TestRunSucceeded, metav1.ConditionTrue)
+		v1alpha1.SetCondition(k6, v1alpha1.TestRunFailed, metav1.ConditionFalse, string(result), "")
+		return
+	}
+
+	var failed []string
+	for _, runner := range runners {
+		if runner.Result != v1alpha1.ResultPassed {
+			failed = append(failed, fmt.Sprintf("%s: %s", runner.Name, runner.Result))
+		}
+		if runner.Result == v1alpha1.ResultRunnerFailed {
+			runnerFailures.WithLabelValues(k6.NamespacedName().Namespace, k6.NamespacedName().Name).Inc()
+
+			exitCode := "no exit code"
+			if runner.ExitCode != nil {
+				exitCode = fmt.Sprintf("exit code %d", *runner.ExitCode)
+			}
+			r.Recorder.Eventf(k6, nil, corev1.EventTypeWarning, "RunnerFailed", "CheckRunners",
+				"runner %s has failed with %s", runner.Name, exitCode)
+		}
+	}
+
+	msg := fmt.Sprintf("%d/%d runners have not passed: %s", len(failed), k6.GetSpec().Parallelism, strings.Join(failed, ", "))
+	if len(failed) == 0 {
+		msg = fmt.Sprintf("%d/%d runners have finished", len(runners), k6.GetSpec().Parallelism)
+	}
+	if result == v1alpha1.ResultThresholdsFailed && thresholdsFailed {
+		msg = "thresholds have failed across all runners: see ThresholdsPassed condition"
+	}
+
+	v1alpha1.UpdateCondition(k6, v1alpha1.TestRunSucceeded, metav1.ConditionFalse)
+	v1alpha1.SetCondition(k6, v1alpha1.TestRunFailed, metav1.ConditionTrue, string(result), msg)
+}
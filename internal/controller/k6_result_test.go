@@ -0,0 +1,126 @@
+package controllers
+
+import (
+	"testing"
+
+	"github.com/grafana/k6-operator/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	k6api "go.k6.io/k6/v2/api/v1"
+	"go.k6.io/k6/v2/lib"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_runnerResult(t *testing.T) {
+	tests := []struct {
+		exitCode int32
+		expected v1alpha1.TestRunResult
+	}{
+		{0, v1alpha1.ResultPassed},
+		{99, v1alpha1.ResultThresholdsFailed},
+		{103, v1alpha1.ResultAborted},
+		{105, v1alpha1.ResultAborted},
+		{107, v1alpha1.ResultScriptError},
+		{104, v1alpha1.ResultScriptError},
+		{1, v1alpha1.ResultRunnerFailed},
+		{137, v1alpha1.ResultRunnerFailed},
+		{-1, v1alpha1.ResultRunnerFailed},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, runnerResult(tt.exitCode), "exit code %d", tt.exitCode)
+	}
+}
+
+func Test_overallResult(t *testing.T) {
+	passed := v1alpha1.RunnerResult{Name: "test-1", Result: v1alpha1.ResultPassed}
+	thresholds := v1alpha1.RunnerResult{Name: "test-2", Result: v1alpha1.ResultThresholdsFailed}
+	crashed := v1alpha1.RunnerResult{Name: "test-3", Result: v1alpha1.ResultRunnerFailed}
+
+	tests := []struct {
+		name        string
+		runners     []v1alpha1.RunnerResult
+		parallelism int32
+		expected    v1alpha1.TestRunResult
+	}{
+		{
+			name:        "all passed",
+			runners:     []v1alpha1.RunnerResult{passed, passed},
+			parallelism: 2,
+			expected:    v1alpha1.ResultPassed,
+		},
+		{
+			name:        "one runner failed thresholds",
+			runners:     []v1alpha1.RunnerResult{passed, thresholds},
+			parallelism: 2,
+			expected:    v1alpha1.ResultThresholdsFailed,
+		},
+		{
+			name:        "crashed runner takes priority",
+			runners:     []v1alpha1.RunnerResult{crashed, thresholds},
+			parallelism: 2,
+			expected:    v1alpha1.ResultRunnerFailed,
+		},
+		{
+			name:        "missing runner",
+			runners:     []v1alpha1.RunnerResult{passed},
+			parallelism: 2,
+			expected:    v1alpha1.ResultRunnerFailed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, overallResult(tt.runners, tt.parallelism))
+		})
+	}
+}
+
+func Test_runnerResults(t *testing.T) {
+	pod := func(job string, state corev1.ContainerState) corev1.Pod {
+		return corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   job + "-abcde",
+				Labels: map[string]string{"job-name": job},
+			},
+			Status: corev1.PodStatus{
+				ContainerStatuses: []corev1.ContainerStatus{
+					{Name: "istio-proxy", State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: 0}}},
+					{Name: "k6", State: state},
+				},
+			},
+		}
+	}
+
+	results := runnerResults([]corev1.Pod{
+		pod("test-2", corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: 99}}),
+		pod("test-1", corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: 0}}),
+		pod("test-3", corev1.ContainerState{Running: &corev1.ContainerStateRunning{}}),
+	})
+
+	zero, thresholds := int32(0), int32(99)
+	assert.Equal(t, []v1alpha1.RunnerResult{
+		{Name: "test-1", ExitCode: &zero, Result: v1alpha1.ResultPassed},
+		{Name: "test-2", ExitCode: &thresholds, Result: v1alpha1.ResultThresholdsFailed},
+		{Name: "test-3", Result: v1alpha1.ResultRunnerFailed},
+	}, results)
+}
+
+func Test_lingeringRunnerResult(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   k6api.Status
+		expected v1alpha1.TestRunResult
+	}{
+		{"ended", k6api.Status{Status: lib.ExecutionStatusEnded}, v1alpha1.ResultPassed},
+		{"ended with failed thresholds", k6api.Status{Status: lib.ExecutionStatusEnded, Tainted: true}, v1alpha1.ResultThresholdsFailed},
+		{"interrupted", k6api.Status{Status: lib.ExecutionStatusInterrupted, Tainted: true}, v1alpha1.ResultAborted},
+		{"marked as failed", k6api.Status{Status: lib.ExecutionStatusMarkedAsFailed}, v1alpha1.ResultScriptError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, lingeringRunnerResult(tt.status))
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package controllers
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	"github.com/grafana/k6-operator/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// countRunners counts runner pods in each state.
+func countRunners(pods []corev1.Pod, desired int32) v1alpha1.RunnersStatus {
+	status := v1alpha1.RunnersStatus{Desired: desired}
+
+	for _, pod := range pods {
+		for _, c := range pod.Status.Conditions {
+			if c.Status != corev1.ConditionTrue {
+				continue
+			}
+			switch c.Type {
+			case corev1.PodScheduled:
+				status.Scheduled++
+			case corev1.PodReady:
+				status.Ready++
+			}
+		}
+
+		switch pod.Status.Phase {
+		case corev1.PodRunning:
+			status.Running++
+		case corev1.PodSucceeded:
+			status.Succeeded++
+		case corev1.PodFailed:
+			status.Failed++
+		}
+	}
+
+	return status
+}
+
+// UpdateRunnersStatus counts the runner pods of the TestRun in .status.runners.
+// It returns true if the counts have changed. The status is not updated here.
+func UpdateRunnersStatus(ctx context.Context, log logr.Logger, k6 *v1alpha1.TestRun, r *TestRunReconciler) bool {
+	pl := &corev1.PodList{}
+	if err := r.List(ctx, pl, k6.ListOptions()); err != nil {
+		log.Error(err, "Could not list pods")
+		return false
+	}
+
+	runners := countRunners(pl.Items, k6.GetSpec().Parallelism)
+	if runners == k6.GetStatus().Runners {
+		return false
+	}
+
+	k6.GetStatus().Runners = runners
+	return true
+}
@@ -0,0 +1,88 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/grafana/k6-operator/api/v1alpha1"
+	"github.com/grafana/k6-operator/pkg/testrun"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_countRunners(t *testing.T) {
+	pod := func(phase corev1.PodPhase, conditions ...corev1.PodConditionType) corev1.Pod {
+		p := corev1.Pod{Status: corev1.PodStatus{Phase: phase}}
+		for _, c := range conditions {
+			p.Status.Conditions = append(p.Status.Conditions, corev1.PodCondition{Type: c, Status: corev1.ConditionTrue})
+		}
+		return p
+	}
+
+	pods := []corev1.Pod{
+		pod(corev1.PodPending),
+		pod(corev1.PodPending, corev1.PodScheduled),
+		pod(corev1.PodRunning, corev1.PodScheduled, corev1.PodReady),
+		pod(corev1.PodSucceeded, corev1.PodScheduled),
+		pod(corev1.PodFailed, corev1.PodScheduled),
+	}
+
+	assert.Equal(t, v1alpha1.RunnersStatus{
+		Desired:   6,
+		Scheduled: 4,
+		Ready:     1,
+		Running:   1,
+		Succeeded: 1,
+		Failed:    1,
+	}, countRunners(pods, 6))
+}
+
+func Test_runners(t *testing.T) {
+	pod := func(name string, phase corev1.PodPhase, ready corev1.ConditionStatus) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name + "-abcde",
+				Namespace: "default",
+				Labels: map[string]string{
+					"app":      "k6",
+					"k6_cr":    "test",
+					"runner":   "true",
+					"job-name": name,
+				},
+			},
+			Status: corev1.PodStatus{
+				Phase:      phase,
+				PodIP:      "10.0.0.1",
+				Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: ready}},
+			},
+		}
+	}
+
+	k6 := testRunInStage("created", time.Now(), nil)
+	r := newTestReconciler(t,
+		pod("test-3", corev1.PodRunning, corev1.ConditionTrue),
+		pod("test-1", corev1.PodRunning, corev1.ConditionTrue),
+		pod("test-2", corev1.PodRunning, corev1.ConditionFalse),
+		pod("test-4", corev1.PodSucceeded, corev1.ConditionFalse),
+	)
+
+	names := func(runners []testrun.Runner) (names []string) {
+		for _, runner := range runners {
+			names = append(names, runner.Name)
+		}
+		return
+	}
+
+	runners, err := r.runners(context.Background(), logr.Discard(), k6, true)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"test-1", "test-3"}, names(runners))
+	assert.Equal(t, testrun.Runner{Name: "test-1", Namespace: "default", Pod: "test-1-abcde", IP: "10.0.0.1"}, runners[0])
+
+	runners, err = r.runners(context.Background(), logr.Discard(), k6, false)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"test-1", "test-2", "test-3"}, names(runners))
+}
@@ -0,0 +1,103 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	"github.com/grafana/k6-operator/api/v1alpha1"
+	"github.com/grafana/k6-operator/pkg/resources/jobs"
+	corev1 "k8s.io/api/core/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ReferenceError is returned when an object referenced by the TestRun,
+// like a script from another namespace or a template, cannot be used.
+type ReferenceError struct {
+	Reason  string
+	Message string
+}
+
+func (e *ReferenceError) Error() string {
+	return e.Message
+}
+
+func (r *TestRunReconciler) reader() client.Reader {
+	if r.APIReader == nil {
+		return r.Client
+	}
+	return r.APIReader
+}
+
+// CopyScript copies the script ConfigMap from another namespace into
+// the namespace of the TestRun, if a ScriptGrant in that namespace permits it.
+func CopyScript(ctx context.Context, log logr.Logger, k6 *v1alpha1.TestRun, r *TestRunReconciler) error {
+	ref := k6.GetSpec().Script.ConfigMap
+
+	grants := &v1alpha1.ScriptGrantList{}
+	if err := r.reader().List(ctx, grants, client.InNamespace(ref.Namespace)); err != nil {
+		log.Error(err, "Could not list script grants")
+		return err
+	}
+
+	permitted := false
+	for i := range grants.Items {
+		if grants.Items[i].Permits(k6.Namespace, "ConfigMap", ref.Name) {
+			permitted = true
+			break
+		}
+	}
+	if !permitted {
+		return &ReferenceError{
+			Reason: "ScriptReferenceNotPermitted",
+			Message: fmt.Sprintf("no ScriptGrant in namespace %s permits TestRuns from namespace %s to reference ConfigMap %s",
+				ref.Namespace, k6.Namespace, ref.Name),
+		}
+	}
+
+	source := &corev1.ConfigMap{}
+	if err := r.reader().Get(ctx, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, source); err != nil {
+		if k8sErrors.IsNotFound(err) {
+			return &ReferenceError{
+				Reason:  "ScriptNotFound",
+				Message: fmt.Sprintf("ConfigMap %s/%s is not found", ref.Namespace, ref.Name),
+			}
+		}
+		log.Error(err, "Could not get the script ConfigMap")
+		return err
+	}
+
+	scriptCopy := jobs.NewScriptCopy(k6, source)
+	if err := ctrl.SetControllerReference(k6, scriptCopy, r.Scheme); err != nil {
+		log.Error(err, "Failed to set controller reference for the copy of the script")
+		return err
+	}
+
+	if err := r.Create(ctx, scriptCopy); err != nil {
+		if !k8sErrors.IsAlreadyExists(err) {
+			log.Error(err, "Failed to create the copy of the script")
+			return err
+		}
+
+		// The name might be taken by an unrelated ConfigMap, which
+		// must be neither used as the script nor deleted later.
+		existing := &corev1.ConfigMap{}
+		if err := r.Get(ctx, client.ObjectKeyFromObject(scriptCopy), existing); err != nil {
+			log.Error(err, "Could not get the copy of the script")
+			return err
+		}
+		if !metav1.IsControlledBy(existing, k6) {
+			return &ReferenceError{
+				Reason:  "ScriptCopyConflict",
+				Message: fmt.Sprintf("ConfigMap %s already exists and is not owned by the TestRun", scriptCopy.Name),
+			}
+		}
+	}
+
+	log.Info(fmt.Sprintf("Script ConfigMap %s/%s was copied into %s", ref.Namespace, ref.Name, scriptCopy.Name))
+	return nil
+}
@@ -0,0 +1,132 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/grafana/k6-operator/api/v1alpha1"
+	"github.com/grafana/k6-operator/pkg/resources/jobs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func testRunWithSharedScript() *v1alpha1.TestRun {
+	return &v1alpha1.TestRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "team-a", UID: "uid"},
+		Spec: v1alpha1.TestRunSpec{
+			Parallelism: 1,
+			Script: v1alpha1.K6Script{
+				ConfigMap: v1alpha1.K6Configmap{Name: "shared", Namespace: "scripts", File: "test.js"},
+			},
+		},
+	}
+}
+
+func sharedScript() *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "shared", Namespace: "scripts"},
+		Data:       map[string]string{"test.js": "export default function () {}"},
+	}
+}
+
+func scriptGrant(from, name string) *v1alpha1.ScriptGrant {
+	return &v1alpha1.ScriptGrant{
+		ObjectMeta: metav1.ObjectMeta{Name: "grant", Namespace: "scripts"},
+		Spec: v1alpha1.ScriptGrantSpec{
+			From: []v1alpha1.ScriptGrantFrom{{Namespace: from}},
+			To:   []v1alpha1.ScriptGrantTo{{Kind: "ConfigMap", Name: name}},
+		},
+	}
+}
+
+func Test_CopyScript(t *testing.T) {
+	tests := []struct {
+		name           string
+		objs           []client.Object
+		expectedReason string
+	}{
+		{
+			name: "permitted by name",
+			objs: []client.Object{sharedScript(), scriptGrant("team-a", "shared")},
+		},
+		{
+			name: "permitted for all ConfigMaps",
+			objs: []client.Object{sharedScript(), scriptGrant("team-a", "")},
+		},
+		{
+			name:           "no grant",
+			objs:           []client.Object{sharedScript()},
+			expectedReason: "ScriptReferenceNotPermitted",
+		},
+		{
+			name:           "grant for another namespace",
+			objs:           []client.Object{sharedScript(), scriptGrant("team-b", "shared")},
+			expectedReason: "ScriptReferenceNotPermitted",
+		},
+		{
+			name:           "grant for another ConfigMap",
+			objs:           []client.Object{sharedScript(), scriptGrant("team-a", "other")},
+			expectedReason: "ScriptReferenceNotPermitted",
+		},
+		{
+			name:           "ConfigMap is missing",
+			objs:           []client.Object{scriptGrant("team-a", "shared")},
+			expectedReason: "ScriptNotFound",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k6 := testRunWithSharedScript()
+			r := newTestReconciler(t, append(tt.objs, k6)...)
+
+			err := CopyScript(context.Background(), logr.Discard(), k6, r)
+
+			scriptCopy := &corev1.ConfigMap{}
+			copyErr := r.Get(context.Background(), types.NamespacedName{Namespace: "team-a", Name: jobs.ScriptCopyName(k6)}, scriptCopy)
+
+			if len(tt.expectedReason) > 0 {
+				var refErr *ReferenceError
+				require.ErrorAs(t, err, &refErr)
+				assert.Equal(t, tt.expectedReason, refErr.Reason)
+				assert.Error(t, copyErr, "the script must not be copied")
+				return
+			}
+
+			require.NoError(t, err)
+			require.NoError(t, copyErr)
+			assert.Equal(t, sharedScript().Data, scriptCopy.Data)
+			assert.True(t, metav1.IsControlledBy(scriptCopy, k6))
+
+			// The copy is created only once.
+			assert.NoError(t, CopyScript(context.Background(), logr.Discard(), k6, r))
+
+			require.NoError(t, deleteScriptCopy(context.Background(), logr.Discard(), k6, r))
+			assert.Error(t, r.Get(context.Background(), client.ObjectKeyFromObject(scriptCopy), &corev1.ConfigMap{}))
+		})
+	}
+}
+
+func Test_CopyScript_ForeignConfigMap(t *testing.T) {
+	k6 := testRunWithSharedScript()
+	foreign := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: jobs.ScriptCopyName(k6), Namespace: "team-a"},
+		Data:       map[string]string{"config": "unrelated"},
+	}
+	r := newTestReconciler(t, sharedScript(), scriptGrant("team-a", "shared"), foreign, k6)
+
+	err := CopyScript(context.Background(), logr.Discard(), k6, r)
+
+	var refErr *ReferenceError
+	require.ErrorAs(t, err, &refErr)
+	assert.Equal(t, "ScriptCopyConflict", refErr.Reason)
+
+	// The cleanup leaves the ConfigMap, which is not owned by the TestRun.
+	require.NoError(t, deleteScriptCopy(context.Background(), logr.Discard(), k6, r))
+	require.NoError(t, r.Get(context.Background(), client.ObjectKeyFromObject(foreign), &corev1.ConfigMap{}))
+}
@@ -4,7 +4,6 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"net/http"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -14,25 +13,14 @@ import (
 	"go.k6.io/k6/v2/cloudapi"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 )
 
-func isServiceReady(log logr.Logger, service *v1.Service) bool {
-	resp, err := http.Get(fmt.Sprintf("http://%v:6565/v1/status", service.Spec.ClusterIP))
-
-	if err != nil {
-		log.Error(err, fmt.Sprintf("failed to get status from %v", service.Name))
-		return false
-	}
-	defer resp.Body.Close() //nolint:errcheck
-
-	return resp.StatusCode < 400
-}
-
 // StartJobs in the Ready phase using a curl container
 func StartJobs(ctx context.Context, log logr.Logger, k6 *v1alpha1.TestRun, r *TestRunReconciler, cloudClient *cloudapi.Client) (res ctrl.Result, err error) {
-	// It may take some time to get Services up, so check in frequently
-	res = ctrl.Result{RequeueAfter: time.Second}
+	// Changes of pod readiness trigger reconcile, so this is only a fallback
+	res = ctrl.Result{RequeueAfter: time.Second * 5}
 
 	if len(k6.GetStatus().TestRunID) > 0 {
 		log = log.WithValues("testRunId", k6.GetStatus().TestRunID)
@@ -40,25 +28,14 @@ func StartJobs(ctx context.Context, log logr.Logger, k6 *v1alpha1.TestRun, r *Te
 
 	log.Info("Waiting for pods to get ready")
 
-	opts := k6.ListOptions()
-
-	pl := &v1.PodList{}
-	if err = r.List(ctx, pl, opts); err != nil {
-		log.Error(err, "Could not list pods")
+	runners, err := r.runners(ctx, log, k6, true)
+	if err != nil {
 		return res, nil
 	}
 
-	var count int
-	for _, pod := range pl.Items {
-		if pod.Status.Phase != "Running" {
-			continue
-		}
-		count++
-	}
-
-	log.Info(fmt.Sprintf("%d/%d runner pods ready", count, k6.GetSpec().Parallelism))
+	log.Info(fmt.Sprintf("%d/%d runner pods ready", len(runners), k6.GetSpec().Parallelism))
 
-	if count != int(k6.GetSpec().Parallelism) {
+	if len(runners) != int(k6.GetSpec().Parallelism) {
 		if t, ok := v1alpha1.LastUpdate(k6, v1alpha1.TestRunRunning); !ok {
 			// this should never happen
 			return res, errors.New("cannot find condition TestRunRunning")
@@ -72,7 +49,7 @@ func StartJobs(ctx context.Context, log logr.Logger, k6 *v1alpha1.TestRun, r *Te
 					events := cloud.ErrorEvent(cloud.K6OperatorStartError).
 						WithDetail(msg).
 						WithAbort()
-					cloud.SendTestRunEvents(cloudClient, k6.TestRunID(), log, events)
+					cloud.SendTestRunEvents(cloudClient, k6.NamespacedName(), k6.TestRunID(), log, events)
 				}
 			}
 		}
@@ -80,38 +57,42 @@ func StartJobs(ctx context.Context, log logr.Logger, k6 *v1alpha1.TestRun, r *Te
 		return res, nil
 	}
 
-	// services
-
-	log.Info("Waiting for services to get ready")
-
-	hostnames, err := r.hostnames(ctx, log, true, opts)
-	log.Info(fmt.Sprintf("err: %v, hostnames: %v", err, hostnames))
-	if err != nil {
-		return ctrl.Result{}, err
-	}
-
-	log.Info(fmt.Sprintf("%d/%d services ready", len(hostnames), k6.GetSpec().Parallelism))
-
 	// setup
 
-	if v1alpha1.IsTrue(k6, v1alpha1.CloudPLZTestRun) {
-		if err, retry := runSetup(ctx, hostnames, log); err != nil {
+	if k6.IsSetupTeardownOnce() && !v1alpha1.IsTrue(k6, v1alpha1.SetupSucceeded) {
+		if err, retry := runSetup(ctx, r.transport(), runners, log); err != nil {
 			if retry {
 				return ctrl.Result{}, err
 			}
 
+			msg := fmt.Sprintf("setup function failed: %v", err)
+			v1alpha1.SetCondition(k6, v1alpha1.SetupSucceeded, metav1.ConditionFalse, "SetupFailed", msg)
+
+			if !v1alpha1.IsTrue(k6, v1alpha1.CloudPLZTestRun) {
+				return r.failTestRun(ctx, log, k6, cloudClient, v1alpha1.ResultScriptError, "SetupFailed", msg)
+			}
+
 			log.Error(err, "Setup function failed, requesting abort.")
+			r.Recorder.Eventf(k6, nil, v1.EventTypeWarning, "SetupFailed", "RunSetup", "%s", msg)
 			events := cloud.ErrorEvent(cloud.SetupError).
-				WithDetail(fmt.Sprintf("setup function failed: %v", err)).
+				WithDetail(msg).
 				WithAbort()
-			cloud.SendTestRunEvents(cloudClient, k6.TestRunID(), log, events)
+			cloud.SendTestRunEvents(cloudClient, k6.NamespacedName(), k6.TestRunID(), log, events)
 
-			return ctrl.Result{Requeue: false}, nil
+			_, err = r.UpdateStatus(ctx, k6, log)
+			return ctrl.Result{Requeue: false}, err
 		}
+
+		v1alpha1.UpdateCondition(k6, v1alpha1.SetupSucceeded, metav1.ConditionTrue)
 	}
 
 	// starter
 
+	// The starter runs inside of the cluster, so it reaches the runners by pod IPs.
+	hostnames := make([]string, 0, len(runners))
+	for _, runner := range runners {
+		hostnames = append(hostnames, runner.IP)
+	}
 	starter := jobs.NewStarterJob(k6, hostnames)
 
 	if err = ctrl.SetControllerReference(k6, starter, r.Scheme); err != nil {
@@ -126,6 +107,8 @@ func StartJobs(ctx context.Context, log logr.Logger, k6 *v1alpha1.TestRun, r *Te
 
 	if created {
 		log.Info("Created starter job")
+		r.Recorder.Eventf(k6, nil, v1.EventTypeNormal, "RunnersReady", "StartRunners",
+			"%d/%d runners are ready", len(hostnames), k6.GetSpec().Parallelism)
 	} else {
 		log.Info("Starter job already exists")
 	}
@@ -133,6 +116,11 @@ func StartJobs(ctx context.Context, log logr.Logger, k6 *v1alpha1.TestRun, r *Te
 	log.Info("Changing stage of TestRun status to started")
 	k6.GetStatus().Stage = "started"
 	v1alpha1.UpdateCondition(k6, v1alpha1.TestRunRunning, metav1.ConditionTrue)
+	// The starter resumes the runners regardless of .spec.paused, so only
+	// later changes of .spec.paused should pause them.
+	k6.GetStatus().ObservedPaused = ptr.To(false)
+	k6.GetStatus().SyncedPaused = ptr.To(k6.IsPaused())
+	v1alpha1.UpdateCondition(k6, v1alpha1.TestRunPaused, metav1.ConditionFalse)
 
 	if updateHappened, err := r.UpdateStatus(ctx, k6, log); err != nil {
 		return ctrl.Result{}, err
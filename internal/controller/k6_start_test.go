@@ -0,0 +1,51 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/grafana/k6-operator/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_StartJobs_PausedSpec(t *testing.T) {
+	k6 := testRunInStage("created", time.Now(), nil)
+	k6.Spec.Parallelism = 1
+	k6.Spec.Paused = "true"
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-1-abcde",
+			Namespace: "default",
+			Labels:    map[string]string{"app": "k6", "k6_cr": "test", "runner": "true", "job-name": "test-1"},
+		},
+		Status: corev1.PodStatus{
+			Phase:      corev1.PodRunning,
+			PodIP:      "10.0.0.1",
+			Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}},
+		},
+	}
+	r := newTestReconciler(t, k6.DeepCopy(), pod)
+
+	_, err := StartJobs(context.Background(), logr.Discard(), k6, r, nil)
+	require.NoError(t, err)
+
+	started := &v1alpha1.TestRun{}
+	require.NoError(t, r.Get(context.Background(), k6.NamespacedName(), started))
+	assert.Equal(t, v1alpha1.Stage("started"), started.Status.Stage)
+	require.NotNil(t, started.Status.ObservedPaused)
+	assert.False(t, *started.Status.ObservedPaused)
+
+	// The starter has resumed the runners, and the value of .spec.paused
+	// from before the start must not pause them again.
+	assert.False(t, started.IsPauseChanged())
+
+	// Only a later change of .spec.paused is applied to the runners.
+	started.Spec.Paused = "false"
+	assert.True(t, started.IsPauseChanged())
+}
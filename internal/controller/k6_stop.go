@@ -2,44 +2,55 @@ package controllers
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/grafana/k6-operator/api/v1alpha1"
 	"github.com/grafana/k6-operator/pkg/resources/jobs"
+	batchv1 "k8s.io/api/batch/v1"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/labels"
 	ctrl "sigs.k8s.io/controller-runtime"
-	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 // StopJobs in the Ready phase using a curl container
-// It assumes that Services of the runners are already up and
-// test is being executed.
+// It assumes that runners are already up and test is being executed.
 func StopJobs(ctx context.Context, log logr.Logger, k6 *v1alpha1.TestRun, r *TestRunReconciler) (res ctrl.Result, err error) {
 	if len(k6.GetStatus().TestRunID) > 0 {
 		log = log.WithValues("testRunId", k6.GetStatus().TestRunID)
 	}
 
-	selector := labels.SelectorFromSet(map[string]string{
-		"app":    "k6",
-		"k6_cr":  k6.NamespacedName().Name,
-		"runner": "true",
-	})
+	if err = createStopJob(ctx, log, k6, r); err != nil {
+		return res, nil
+	}
 
-	opts := &client.ListOptions{LabelSelector: selector, Namespace: k6.NamespacedName().Namespace}
+	log.Info("Changing stage of TestRun status to stopped")
+	k6.GetStatus().Stage = "stopped"
+	v1alpha1.UpdateCondition(k6, v1alpha1.TestRunRunning, metav1.ConditionFalse)
+	v1alpha1.UpdateCondition(k6, v1alpha1.CloudTestRunAborted, metav1.ConditionTrue)
 
-	var hostnames []string
-	sl := &v1.ServiceList{}
+	if updateHappened, err := r.UpdateStatus(ctx, k6, log); err != nil {
+		return ctrl.Result{}, err
+	} else if updateHappened {
+		return ctrl.Result{RequeueAfter: time.Second}, nil
+	}
+	return ctrl.Result{}, nil
+}
 
-	if err = r.List(ctx, sl, opts); err != nil {
-		log.Error(err, "Could not list services")
-		return res, nil
+// createStopJob launches the stopper job which sends the stop call
+// to all runners of the test run.
+func createStopJob(ctx context.Context, log logr.Logger, k6 *v1alpha1.TestRun, r *TestRunReconciler) (err error) {
+	// The stopper runs inside of the cluster, so it reaches the runners by pod IPs,
+	// as the starter does. Runners which have already finished are skipped.
+	runners, err := r.runners(ctx, log, k6, false)
+	if err != nil {
+		return err
 	}
 
-	for _, service := range sl.Items {
-		hostnames = append(hostnames, service.Spec.ClusterIP)
+	hostnames := make([]string, 0, len(runners))
+	for _, runner := range runners {
+		hostnames = append(hostnames, runner.IP)
 	}
 
 	stopJob := jobs.NewStopJob(k6, hostnames)
@@ -51,7 +62,7 @@ func StopJobs(ctx context.Context, log logr.Logger, k6 *v1alpha1.TestRun, r *Tes
 	created, err := createJobIfNotExists(ctx, r.Client, stopJob)
 	if err != nil {
 		log.Error(err, "Failed to launch k6 test stop job.")
-		return res, nil
+		return err
 	}
 
 	if created {
@@ -60,10 +71,40 @@ func StopJobs(ctx context.Context, log logr.Logger, k6 *v1alpha1.TestRun, r *Tes
 		log.Info("Stop job already exists")
 	}
 
+	return nil
+}
+
+// StopJobsByUser handles the StopAnnotation. A started test run is stopped
+// gracefully with the stopper job so that runners can finish execution
+// and print the end-of-test summary. A test run that has not started yet
+// has nothing to stop, so its jobs are simply deleted.
+func StopJobsByUser(ctx context.Context, log logr.Logger, k6 *v1alpha1.TestRun, r *TestRunReconciler) (res ctrl.Result, err error) {
+	if len(k6.GetStatus().TestRunID) > 0 {
+		log = log.WithValues("testRunId", k6.GetStatus().TestRunID)
+	}
+
+	log.Info(fmt.Sprintf("Received a stop request with %s annotation: stopping the test.", v1alpha1.StopAnnotation))
+
+	if k6.GetStatus().Stage == "started" {
+		if err = createStopJob(ctx, log, k6, r); err != nil {
+			return ctrl.Result{RequeueAfter: time.Second}, nil
+		}
+	} else {
+		if err = KillInitializer(ctx, log, k6, r); err != nil {
+			return ctrl.Result{RequeueAfter: time.Second}, err
+		}
+		if _, err = KillJobs(ctx, log, k6, r); err != nil {
+			return ctrl.Result{RequeueAfter: time.Second}, err
+		}
+	}
+
+	msg := fmt.Sprintf("test run was stopped by user with %s annotation", v1alpha1.StopAnnotation)
+	r.Recorder.Eventf(k6, nil, v1.EventTypeNormal, "StopRequested", "Stopping", "%s", msg)
+
 	log.Info("Changing stage of TestRun status to stopped")
 	k6.GetStatus().Stage = "stopped"
 	v1alpha1.UpdateCondition(k6, v1alpha1.TestRunRunning, metav1.ConditionFalse)
-	v1alpha1.UpdateCondition(k6, v1alpha1.CloudTestRunAborted, metav1.ConditionTrue)
+	v1alpha1.SetCondition(k6, v1alpha1.TestRunStoppedByUser, metav1.ConditionTrue, "StopRequested", msg)
 
 	if updateHappened, err := r.UpdateStatus(ctx, k6, log); err != nil {
 		return ctrl.Result{}, err
@@ -72,3 +113,23 @@ func StopJobs(ctx context.Context, log logr.Logger, k6 *v1alpha1.TestRun, r *Tes
 	}
 	return ctrl.Result{}, nil
 }
+
+// RunnersExited checks if there are no more active runner jobs,
+// i.e. all runners have either completed or have been deleted.
+func RunnersExited(ctx context.Context, log logr.Logger, k6 *v1alpha1.TestRun, r *TestRunReconciler) bool {
+	jl := &batchv1.JobList{}
+	if err := r.List(ctx, jl, k6.ListOptions()); err != nil {
+		log.Error(err, "Could not list jobs")
+		return false
+	}
+
+	var active int
+	for _, job := range jl.Items {
+		if job.Status.Active != 0 {
+			active++
+		}
+	}
+
+	log.Info(fmt.Sprintf("%d/%d runner jobs are still active", active, len(jl.Items)))
+	return active == 0
+}
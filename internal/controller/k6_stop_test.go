@@ -0,0 +1,139 @@
+package controllers
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/grafana/k6-operator/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/events"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func runnerJob(name string, active int32) *batchv1.Job {
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+			Labels: map[string]string{
+				"app":    "k6",
+				"k6_cr":  "test",
+				"runner": "true",
+			},
+		},
+		Status: batchv1.JobStatus{Active: active},
+	}
+}
+
+func newTestReconciler(t *testing.T, objs ...client.Object) *TestRunReconciler {
+	scheme := runtime.NewScheme()
+	require.NoError(t, batchv1.AddToScheme(scheme))
+	require.NoError(t, corev1.AddToScheme(scheme))
+	require.NoError(t, v1alpha1.AddToScheme(scheme))
+
+	return &TestRunReconciler{
+		Client: fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithObjects(objs...).
+			WithStatusSubresource(&v1alpha1.TestRun{}).
+			Build(),
+		Scheme:   scheme,
+		Recorder: events.NewFakeRecorder(10),
+	}
+}
+
+func Test_RunnersExited(t *testing.T) {
+	k6 := &v1alpha1.TestRun{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"}}
+
+	tests := []struct {
+		name     string
+		jobs     []client.Object
+		expected bool
+	}{
+		{
+			name:     "no runner jobs",
+			expected: true,
+		},
+		{
+			name:     "all runner jobs have completed",
+			jobs:     []client.Object{runnerJob("test-1", 0), runnerJob("test-2", 0)},
+			expected: true,
+		},
+		{
+			name:     "one runner job is still active",
+			jobs:     []client.Object{runnerJob("test-1", 0), runnerJob("test-2", 1)},
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestReconciler(t, tt.jobs...)
+			assert.Equal(t, tt.expected, RunnersExited(context.Background(), logr.Discard(), k6, r))
+		})
+	}
+}
+
+func Test_StopJobsByUser_BeforeStart(t *testing.T) {
+	k6 := &v1alpha1.TestRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "test",
+			Namespace:   "default",
+			Annotations: map[string]string{v1alpha1.StopAnnotation: "true"},
+		},
+		Spec: v1alpha1.TestRunSpec{Parallelism: 2},
+		Status: v1alpha1.TestRunStatus{
+			Stage: "created",
+		},
+	}
+	r := newTestReconciler(t, k6.DeepCopy(), runnerJob("test-1", 1), runnerJob("test-2", 1))
+
+	_, err := StopJobsByUser(context.Background(), logr.Discard(), k6, r)
+	require.NoError(t, err)
+
+	jl := &batchv1.JobList{}
+	require.NoError(t, r.List(context.Background(), jl, k6.ListOptions()))
+	assert.Empty(t, jl.Items)
+
+	updated := &v1alpha1.TestRun{}
+	require.NoError(t, r.Get(context.Background(), k6.NamespacedName(), updated))
+	assert.Equal(t, v1alpha1.Stage("stopped"), updated.Status.Stage)
+	assert.True(t, v1alpha1.IsTrue(updated, v1alpha1.TestRunStoppedByUser))
+}
+
+func Test_createStopJob(t *testing.T) {
+	k6 := &v1alpha1.TestRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default", UID: "uid"},
+		Spec:       v1alpha1.TestRunSpec{Parallelism: 2},
+	}
+	pod := func(name string, phase corev1.PodPhase, ip string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name + "-abcde",
+				Namespace: "default",
+				Labels:    map[string]string{"app": "k6", "k6_cr": "test", "runner": "true", "job-name": name},
+			},
+			Status: corev1.PodStatus{Phase: phase, PodIP: ip},
+		}
+	}
+	r := newTestReconciler(t, k6.DeepCopy(),
+		pod("test-1", corev1.PodRunning, "10.0.0.1"),
+		pod("test-2", corev1.PodSucceeded, "10.0.0.2"),
+	)
+
+	require.NoError(t, createStopJob(context.Background(), logr.Discard(), k6, r))
+
+	stopper := &batchv1.Job{}
+	require.NoError(t, r.Get(context.Background(), client.ObjectKey{Namespace: "default", Name: "test-stopper"}, stopper))
+	command := strings.Join(stopper.Spec.Template.Spec.Containers[0].Command, " ")
+	assert.Contains(t, command, "http://10.0.0.1:6565/v1/status")
+	assert.NotContains(t, command, "10.0.0.2")
+}
@@ -2,48 +2,29 @@ package controllers
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"io"
-	"net/http"
 
 	"github.com/go-logr/logr"
 	"github.com/grafana/k6-operator/api/v1alpha1"
-	k6api "go.k6.io/k6/v2/api/v1"
+	"github.com/grafana/k6-operator/pkg/testrun"
 	batchv1 "k8s.io/api/batch/v1"
-	v1 "k8s.io/api/core/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-func isJobRunning(log logr.Logger, service *v1.Service) bool {
-	resp, err := http.Get(fmt.Sprintf("http://%v:6565/v1/status", service.Spec.ClusterIP))
+// isJobRunning checks via k6 REST API whether the runner is still executing
+// the test. The runner is expected to be ready, so if its status cannot be
+// retrieved, it is assumed to be still running.
+func isJobRunning(ctx context.Context, log logr.Logger, t testrun.Transport, runner testrun.Runner) bool {
+	status, err := testrun.GetStatus(ctx, t, runner)
 	if err != nil {
-		return false
-	}
-	defer resp.Body.Close() //nolint:errcheck
-
-	// Response has been received so assume the job is running.
-
-	if resp.StatusCode >= 400 {
-		log.Error(err, fmt.Sprintf("status from from runner job %v is %d", service.Name, resp.StatusCode))
-		return true
-	}
-
-	data, err := io.ReadAll(resp.Body)
-	if err != nil {
-		log.Error(err, fmt.Sprintf("Error on reading status of the runner job %v", service.Name))
+		log.Info(fmt.Sprintf("Could not get status from %s: %v", runner.Name, err))
 		return true
 	}
 
-	var status k6api.StatusJSONAPI
-	if err := json.Unmarshal(data, &status); err != nil {
-		log.Error(err, fmt.Sprintf("Error on parsing status of the runner job %v", service.Name))
-		return true
-	}
-
-	return status.Status().Running
+	return status.Running
 }
 
 // StoppedJobs checks if the runners pods have stopped execution.
@@ -54,25 +35,15 @@ func StoppedJobs(ctx context.Context, log logr.Logger, k6 *v1alpha1.TestRun, r *
 
 	log.Info("Waiting for pods to stop the test run")
 
-	selector := labels.SelectorFromSet(map[string]string{
-		"app":    "k6",
-		"k6_cr":  k6.NamespacedName().Name,
-		"runner": "true",
-	})
-
-	opts := &client.ListOptions{LabelSelector: selector, Namespace: k6.NamespacedName().Namespace}
-
-	sl := &v1.ServiceList{}
-
-	if err := r.List(ctx, sl, opts); err != nil {
-		log.Error(err, "Could not list services")
+	// Runners whose pods are gone or not ready any more have stopped execution.
+	runners, err := r.runners(ctx, log, k6, true)
+	if err != nil {
 		return
 	}
 
 	var runningJobs int32
-	for _, service := range sl.Items {
-
-		if isJobRunning(log, &service) {
+	for _, runner := range runners {
+		if isJobRunning(ctx, log, r.transport(), runner) {
 			runningJobs++
 		}
 	}
@@ -125,3 +96,21 @@ func KillJobs(ctx context.Context, log logr.Logger, k6 *v1alpha1.TestRun, r *Tes
 
 	return deleteCount == len(jl.Items), nil
 }
+
+// KillInitializer deletes the initializer job, if there is one,
+// together with its pod.
+func KillInitializer(ctx context.Context, log logr.Logger, k6 *v1alpha1.TestRun, r *TestRunReconciler) error {
+	initializer := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("%s-initializer", k6.NamespacedName().Name),
+			Namespace: k6.NamespacedName().Namespace,
+		},
+	}
+
+	propagationPolicy := client.PropagationPolicy(metav1.DeletePropagationBackground)
+	if err := r.Delete(ctx, initializer, propagationPolicy); err != nil && !k8sErrors.IsNotFound(err) {
+		log.Error(err, "Failed to delete initializer job")
+		return err
+	}
+	return nil
+}
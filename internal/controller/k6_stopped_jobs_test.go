@@ -0,0 +1,105 @@
+package controllers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/grafana/k6-operator/pkg/testrun"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	k6Client "go.k6.io/k6/v2/api/v1/client"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// serverTransport sends all requests of the runners to a test server.
+type serverTransport struct {
+	url string
+}
+
+func (t serverTransport) Client(_ testrun.Runner, timeout time.Duration) (*k6Client.Client, error) {
+	return k6Client.New(strings.TrimPrefix(t.url, "http://"), k6Client.WithHTTPClient(&http.Client{Timeout: timeout}))
+}
+
+func Test_isJobRunning(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   int
+		body     string
+		expected bool
+	}{
+		{
+			name:     "test is running",
+			status:   http.StatusOK,
+			body:     `{"data":{"type":"status","id":"default","attributes":{"running":true}}}`,
+			expected: true,
+		},
+		{
+			name:     "test has stopped",
+			status:   http.StatusOK,
+			body:     `{"data":{"type":"status","id":"default","attributes":{"running":false}}}`,
+			expected: false,
+		},
+		{
+			name:     "server error",
+			status:   http.StatusInternalServerError,
+			body:     `{"errors":[{"detail":"internal error"}]}`,
+			expected: true,
+		},
+		{
+			name:     "invalid status",
+			status:   http.StatusOK,
+			body:     `not json`,
+			expected: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			running := isJobRunning(context.Background(), logr.Discard(), serverTransport{url: server.URL}, testrun.Runner{Name: "test-1"})
+			assert.Equal(t, tt.expected, running)
+		})
+	}
+}
+
+func Test_isJobRunning_Unreachable(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	url := server.URL
+	server.Close()
+
+	running := isJobRunning(context.Background(), logr.Discard(), serverTransport{url: url}, testrun.Runner{Name: "test-1"})
+	require.True(t, running)
+}
+
+func Test_StoppedJobs_NotReadyRunner(t *testing.T) {
+	k6 := testRunInStage("stopped", time.Now(), nil)
+	k6.Spec.Parallelism = 1
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-1-abcde",
+			Namespace: "default",
+			Labels:    map[string]string{"app": "k6", "k6_cr": "test", "runner": "true", "job-name": "test-1"},
+		},
+		Status: corev1.PodStatus{
+			Phase:      corev1.PodRunning,
+			PodIP:      "10.0.0.1",
+			Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionFalse}},
+		},
+	}
+	r := newTestReconciler(t, k6.DeepCopy(), pod)
+
+	// The runner is not ready, so its status is not requested at all.
+	assert.True(t, StoppedJobs(context.Background(), logr.Discard(), k6, r))
+}
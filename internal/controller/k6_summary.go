@@ -0,0 +1,77 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	"github.com/grafana/k6-operator/api/v1alpha1"
+	"github.com/grafana/k6-operator/pkg/testrun"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// combinedSummaryKey is the key of the summary across all runners in the summary ConfigMap.
+// Summaries of the runners are stored under the names of their Jobs.
+const combinedSummaryKey = "combined.json"
+
+// CollectSummary retrieves end-of-test summaries from the lingering runners
+// and stores them in a ConfigMap owned by the TestRun. The status is not updated here.
+func CollectSummary(ctx context.Context, log logr.Logger, k6 *v1alpha1.TestRun, r *TestRunReconciler) error {
+	log.Info("Collecting end-of-test summaries from the runners")
+
+	runners, err := r.runners(ctx, log, k6, false)
+	if err != nil {
+		return err
+	}
+
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("%s-summary", k6.NamespacedName().Name),
+			Namespace: k6.NamespacedName().Namespace,
+		},
+	}
+
+	var summaries []testrun.Summary
+	data := map[string]string{}
+
+	for _, runner := range runners {
+		list, err := testrun.GetMetrics(ctx, r.transport(), runner)
+		if err != nil {
+			log.Error(err, fmt.Sprintf("Could not get metrics from %s", runner.Name))
+			return err
+		}
+
+		summary := testrun.NewSummary(list)
+		summaries = append(summaries, summary)
+
+		if data[runner.Name+".json"], err = marshalSummary(summary); err != nil {
+			return err
+		}
+	}
+
+	if data[combinedSummaryKey], err = marshalSummary(testrun.CombineSummaries(summaries)); err != nil {
+		return err
+	}
+
+	if _, err = controllerutil.CreateOrUpdate(ctx, r.Client, cm, func() error {
+		cm.Data = data
+		return ctrl.SetControllerReference(k6, cm, r.Scheme)
+	}); err != nil {
+		log.Error(err, "Failed to store summaries in a ConfigMap")
+		return err
+	}
+
+	log.Info(fmt.Sprintf("Summaries of %d runners are stored in ConfigMap %s", len(summaries), cm.Name))
+
+	k6.GetStatus().SummaryConfigMap = cm.Name
+	return nil
+}
+
+func marshalSummary(summary testrun.Summary) (string, error) {
+	b, err := json.MarshalIndent(summary, "", "  ")
+	return string(b), err
+}
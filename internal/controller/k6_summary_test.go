@@ -0,0 +1,31 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/grafana/k6-operator/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func Test_CollectSummary_NoRunners(t *testing.T) {
+	k6 := &v1alpha1.TestRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default", UID: "1234"},
+		Spec:       v1alpha1.TestRunSpec{Parallelism: 1, CollectSummary: true},
+	}
+	r := newTestReconciler(t, k6.DeepCopy())
+
+	require.NoError(t, CollectSummary(context.Background(), logr.Discard(), k6, r))
+	assert.Equal(t, "test-summary", k6.Status.SummaryConfigMap)
+
+	cm := &corev1.ConfigMap{}
+	require.NoError(t, r.Get(context.Background(), types.NamespacedName{Name: "test-summary", Namespace: "default"}, cm))
+	assert.Equal(t, map[string]string{combinedSummaryKey: "{}"}, cm.Data)
+	require.Len(t, cm.OwnerReferences, 1)
+	assert.Equal(t, "test", cm.OwnerReferences[0].Name)
+}
@@ -0,0 +1,67 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	"github.com/grafana/k6-operator/api/v1alpha1"
+	"github.com/grafana/k6-operator/pkg/testrun"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ResolveTemplate merges the spec of the TestRun with the template from
+// .spec.templateRef and stores the result in the status, so that later
+// changes of the template do not affect the started test run.
+func ResolveTemplate(ctx context.Context, log logr.Logger, k6 *v1alpha1.TestRun, r *TestRunReconciler) error {
+	ref := k6.Spec.TemplateRef
+
+	var (
+		template *v1alpha1.TestRunSpec
+		key      = types.NamespacedName{Name: ref.Name}
+		obj      client.Object
+	)
+	switch ref.Kind {
+	case v1alpha1.ClusterTestRunTemplateKind:
+		t := &v1alpha1.ClusterTestRunTemplate{}
+		obj, template = t, &t.Spec
+	default:
+		t := &v1alpha1.TestRunTemplate{}
+		obj, template = t, &t.Spec
+		key.Namespace = k6.Namespace
+	}
+
+	// Templates are read without the cache: they are needed once per test
+	// run and cluster-scoped ones are outside of the watched namespaces.
+	if err := r.reader().Get(ctx, key, obj); err != nil {
+		if k8sErrors.IsNotFound(err) {
+			return &ReferenceError{
+				Reason:  "TemplateNotFound",
+				Message: fmt.Sprintf("%s %s is not found", templateKind(ref), ref.Name),
+			}
+		}
+		log.Error(err, "Could not get the template")
+		return err
+	}
+
+	resolved, err := testrun.MergeSpec(template, &k6.Spec)
+	if err != nil {
+		return &ReferenceError{
+			Reason:  "InvalidTemplate",
+			Message: fmt.Sprintf("cannot apply %s %s: %v", templateKind(ref), ref.Name, err),
+		}
+	}
+
+	log.Info(fmt.Sprintf("Spec was resolved with %s %s", templateKind(ref), ref.Name))
+	k6.GetStatus().ResolvedSpec = resolved
+	return nil
+}
+
+func templateKind(ref *v1alpha1.TestRunTemplateReference) string {
+	if len(ref.Kind) == 0 {
+		return v1alpha1.TestRunTemplateKind
+	}
+	return ref.Kind
+}
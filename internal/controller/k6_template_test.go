@@ -0,0 +1,136 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/grafana/k6-operator/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func templateSpec(image string) v1alpha1.TestRunSpec {
+	return v1alpha1.TestRunSpec{
+		Parallelism: 2,
+		Script:      v1alpha1.K6Script{ConfigMap: v1alpha1.K6Configmap{Name: "test", File: "test.js"}},
+		Runner:      v1alpha1.Pod{Image: image},
+	}
+}
+
+func Test_ResolveTemplate(t *testing.T) {
+	templates := []client.Object{
+		&v1alpha1.TestRunTemplate{
+			ObjectMeta: metav1.ObjectMeta{Name: "defaults", Namespace: "default"},
+			Spec:       templateSpec("grafana/k6:namespaced"),
+		},
+		&v1alpha1.TestRunTemplate{
+			ObjectMeta: metav1.ObjectMeta{Name: "other-namespace", Namespace: "other"},
+			Spec:       templateSpec("grafana/k6:other"),
+		},
+		&v1alpha1.ClusterTestRunTemplate{
+			ObjectMeta: metav1.ObjectMeta{Name: "defaults"},
+			Spec:       templateSpec("grafana/k6:cluster"),
+		},
+		&v1alpha1.TestRunTemplate{
+			ObjectMeta: metav1.ObjectMeta{Name: "nested", Namespace: "default"},
+			Spec: v1alpha1.TestRunSpec{
+				TemplateRef: &v1alpha1.TestRunTemplateReference{Name: "defaults"},
+			},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		ref            v1alpha1.TestRunTemplateReference
+		expectedImage  string
+		expectedReason string
+	}{
+		{
+			name:          "TestRunTemplate by default",
+			ref:           v1alpha1.TestRunTemplateReference{Name: "defaults"},
+			expectedImage: "grafana/k6:namespaced",
+		},
+		{
+			name:          "ClusterTestRunTemplate",
+			ref:           v1alpha1.TestRunTemplateReference{Kind: v1alpha1.ClusterTestRunTemplateKind, Name: "defaults"},
+			expectedImage: "grafana/k6:cluster",
+		},
+		{
+			name:           "TestRunTemplate of another namespace",
+			ref:            v1alpha1.TestRunTemplateReference{Name: "other-namespace"},
+			expectedReason: "TemplateNotFound",
+		},
+		{
+			name:           "template referencing another template",
+			ref:            v1alpha1.TestRunTemplateReference{Name: "nested"},
+			expectedReason: "InvalidTemplate",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k6 := &v1alpha1.TestRun{
+				ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+				Spec: v1alpha1.TestRunSpec{
+					TemplateRef: &tt.ref,
+					Parallelism: 4,
+				},
+			}
+			r := newTestReconciler(t, append(templates, k6)...)
+
+			err := ResolveTemplate(context.Background(), logr.Discard(), k6, r)
+
+			if len(tt.expectedReason) > 0 {
+				var refErr *ReferenceError
+				require.ErrorAs(t, err, &refErr)
+				assert.Equal(t, tt.expectedReason, refErr.Reason)
+				assert.Nil(t, k6.Status.ResolvedSpec)
+				return
+			}
+
+			require.NoError(t, err)
+			require.NotNil(t, k6.Status.ResolvedSpec)
+			assert.Same(t, k6.Status.ResolvedSpec, k6.GetSpec())
+			assert.Equal(t, tt.expectedImage, k6.GetSpec().Runner.Image)
+			assert.Equal(t, int32(4), k6.GetSpec().Parallelism)
+			assert.Equal(t, "test", k6.GetSpec().Script.ConfigMap.Name)
+		})
+	}
+}
+
+func Test_ResolveTemplate_RuntimeFields(t *testing.T) {
+	spec := templateSpec("grafana/k6:namespaced")
+	spec.Paused = "true"
+	spec.CleanupPolicy = &v1alpha1.CleanupPolicy{Type: v1alpha1.CleanupDeleteTestRun}
+	template := &v1alpha1.TestRunTemplate{
+		ObjectMeta: metav1.ObjectMeta{Name: "defaults", Namespace: "default"},
+		Spec:       spec,
+	}
+	k6 := &v1alpha1.TestRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+		Spec: v1alpha1.TestRunSpec{
+			TemplateRef: &v1alpha1.TestRunTemplateReference{Name: "defaults"},
+		},
+	}
+	r := newTestReconciler(t, template, k6)
+
+	require.NoError(t, ResolveTemplate(context.Background(), logr.Discard(), k6, r))
+	k6.Status.SyncedPaused = ptrTo(true)
+	assert.True(t, k6.IsPaused())
+	assert.False(t, k6.IsPauseChanged())
+	assert.Equal(t, v1alpha1.CleanupDeleteTestRun, k6.GetCleanupPolicy().Type)
+
+	// Changes of the spec after the start are not hidden by the resolved spec.
+	k6.Spec.Paused = "false"
+	k6.Spec.CleanupPolicy = &v1alpha1.CleanupPolicy{Type: v1alpha1.CleanupDeleteResources}
+	k6.Spec.Timeouts = &v1alpha1.TestRunTimeouts{Run: &metav1.Duration{Duration: time.Hour}}
+
+	assert.False(t, k6.IsPaused())
+	assert.True(t, k6.IsPauseChanged())
+	assert.Equal(t, v1alpha1.CleanupDeleteResources, k6.GetCleanupPolicy().Type)
+	assert.Equal(t, time.Hour, k6.GetTimeouts().Run.Duration)
+}
@@ -0,0 +1,143 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/grafana/k6-operator/api/v1alpha1"
+	"github.com/grafana/k6-operator/pkg/testrun"
+	"go.k6.io/k6/v2/metrics"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// thresholdStatuses converts thresholds from the output of k6 inspect,
+// sorted by metric so that the status is stable between reconciles.
+func thresholdStatuses(thresholds map[string]*metrics.Thresholds) []v1alpha1.ThresholdStatus {
+	var statuses []v1alpha1.ThresholdStatus
+
+	for metric, ts := range thresholds {
+		if ts == nil {
+			continue
+		}
+		for _, t := range ts.Thresholds {
+			status := v1alpha1.ThresholdStatus{
+				Metric:      metric,
+				Threshold:   t.Source,
+				AbortOnFail: t.AbortOnFail,
+			}
+			if t.AbortGracePeriod.Valid {
+				status.AbortGracePeriod = &metav1.Duration{Duration: time.Duration(t.AbortGracePeriod.Duration)}
+			}
+			statuses = append(statuses, status)
+		}
+	}
+
+	slices.SortStableFunc(statuses, func(a, b v1alpha1.ThresholdStatus) int {
+		return strings.Compare(a.Metric, b.Metric)
+	})
+
+	return statuses
+}
+
+// unsupportedThresholds returns the thresholds which cannot be evaluated
+// by the operator, because k6 REST API does not expose their values.
+func unsupportedThresholds(thresholds []v1alpha1.ThresholdStatus) []string {
+	var unsupported []string
+	for _, t := range thresholds {
+		threshold, err := testrun.ParseThreshold(t.Threshold)
+		if err != nil || !threshold.IsSupported() {
+			unsupported = append(unsupported, fmt.Sprintf("%s: %s", t.Metric, t.Threshold))
+		}
+	}
+	return unsupported
+}
+
+// evaluateThresholds sets the outcome of each threshold against the summaries
+// of all runners. It returns the failed thresholds, the thresholds without
+// values to evaluate, and whether the test run should be aborted because of
+// the failed ones, given how long the test has been running.
+func evaluateThresholds(thresholds []v1alpha1.ThresholdStatus, summaries []testrun.Summary, running time.Duration) (
+	failed, unknown []string, abort bool,
+) {
+	for i := range thresholds {
+		t := &thresholds[i]
+		t.Passed = nil
+
+		threshold, err := testrun.ParseThreshold(t.Threshold)
+		if err != nil {
+			unknown = append(unknown, fmt.Sprintf("%s: %s", t.Metric, t.Threshold))
+			continue
+		}
+
+		passed, ok := threshold.Evaluate(t.Metric, summaries)
+		if !ok {
+			unknown = append(unknown, fmt.Sprintf("%s: %s", t.Metric, t.Threshold))
+			continue
+		}
+		t.Passed = &passed
+
+		if passed {
+			continue
+		}
+
+		failed = append(failed, fmt.Sprintf("%s: %s", t.Metric, t.Threshold))
+
+		if t.AbortOnFail && (t.AbortGracePeriod == nil || t.AbortGracePeriod.Duration < running) {
+			abort = true
+		}
+	}
+
+	return failed, unknown, abort
+}
+
+// EvaluateThresholds retrieves metrics from all runners and evaluates
+// thresholds of the test run against them. It sets the ThresholdsPassed
+// condition and returns true if the test run must be aborted because
+// of a failed threshold with abortOnFail. The status is not updated here.
+func EvaluateThresholds(ctx context.Context, log logr.Logger, k6 *v1alpha1.TestRun, r *TestRunReconciler) (abort bool, err error) {
+	thresholds := k6.GetStatus().Thresholds
+	if len(thresholds) == 0 {
+		return false, nil
+	}
+
+	runners, err := r.runners(ctx, log, k6, false)
+	if err != nil {
+		return false, err
+	}
+
+	summaries := make([]testrun.Summary, 0, len(runners))
+	for _, runner := range runners {
+		list, err := testrun.GetMetrics(ctx, r.transport(), runner)
+		if err != nil {
+			log.Error(err, fmt.Sprintf("Could not get metrics from %s", runner.Name))
+			return false, err
+		}
+		summaries = append(summaries, testrun.NewSummary(list))
+	}
+
+	runningTime, _ := v1alpha1.LastUpdate(k6, v1alpha1.TestRunRunning)
+
+	failed, unknown, abort := evaluateThresholds(thresholds, summaries, time.Since(runningTime))
+
+	// A threshold without values is not reported as passed.
+	if len(failed) == 0 && len(unknown) > 0 {
+		msg := fmt.Sprintf("%d/%d thresholds have no values to evaluate: %s", len(unknown), len(thresholds), strings.Join(unknown, ", "))
+		v1alpha1.SetCondition(k6, v1alpha1.ThresholdsPassed, metav1.ConditionUnknown, "ThresholdsNotEvaluated", msg)
+		return false, nil
+	}
+
+	if len(failed) == 0 {
+		v1alpha1.UpdateCondition(k6, v1alpha1.ThresholdsPassed, metav1.ConditionTrue)
+		return false, nil
+	}
+
+	msg := fmt.Sprintf("%d/%d thresholds have failed: %s", len(failed), len(thresholds), strings.Join(failed, ", "))
+	log.Info(msg)
+	v1alpha1.SetCondition(k6, v1alpha1.ThresholdsPassed, metav1.ConditionFalse, "ThresholdsFailed", msg)
+
+	return abort, nil
+}
@@ -0,0 +1,108 @@
+package controllers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/grafana/k6-operator/api/v1alpha1"
+	"github.com/grafana/k6-operator/pkg/testrun"
+	"github.com/stretchr/testify/assert"
+	"go.k6.io/k6/v2/lib/types"
+	"go.k6.io/k6/v2/metrics"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_thresholdStatuses(t *testing.T) {
+	duration := metrics.NewThresholds([]string{"p(95)<200", "avg<100"})
+	duration.Thresholds[0].AbortOnFail = true
+	duration.Thresholds[0].AbortGracePeriod = types.NullDurationFrom(10 * time.Second)
+	failed := metrics.NewThresholds([]string{"rate<0.01"})
+
+	assert.Equal(t, []v1alpha1.ThresholdStatus{
+		{Metric: "http_req_duration", Threshold: "p(95)<200", AbortOnFail: true, AbortGracePeriod: &metav1.Duration{Duration: 10 * time.Second}},
+		{Metric: "http_req_duration", Threshold: "avg<100"},
+		{Metric: "http_req_failed", Threshold: "rate<0.01"},
+	}, thresholdStatuses(map[string]*metrics.Thresholds{
+		"http_req_failed":   &failed,
+		"http_req_duration": &duration,
+	}))
+}
+
+func Test_unsupportedThresholds(t *testing.T) {
+	assert.Equal(t, []string{"http_req_duration: p(99)<300"}, unsupportedThresholds([]v1alpha1.ThresholdStatus{
+		{Metric: "http_req_duration", Threshold: "p(95)<200"},
+		{Metric: "http_req_duration", Threshold: "p(99)<300"},
+		{Metric: "http_req_failed", Threshold: "rate<0.01"},
+	}))
+}
+
+func Test_evaluateThresholds(t *testing.T) {
+	summaries := []testrun.Summary{
+		{"http_req_duration": {Type: "trend", Values: map[string]float64{"avg": 50, "p(95)": 150}}},
+		{"http_req_duration": {Type: "trend", Values: map[string]float64{"avg": 60, "p(95)": 250}}},
+	}
+	grace := &metav1.Duration{Duration: time.Minute}
+
+	tests := []struct {
+		name        string
+		thresholds  []v1alpha1.ThresholdStatus
+		running     time.Duration
+		wantFailed  []string
+		wantUnknown []string
+		wantAbort   bool
+		wantOutcome []*bool
+	}{
+		{
+			name: "passed or without values",
+			thresholds: []v1alpha1.ThresholdStatus{
+				{Metric: "http_req_duration", Threshold: "avg<100", AbortOnFail: true},
+				{Metric: "http_reqs", Threshold: "count>0"},
+			},
+			wantUnknown: []string{"http_reqs: count>0"},
+			wantOutcome: []*bool{ptrTo(true), nil},
+		},
+		{
+			name: "failed on one runner",
+			thresholds: []v1alpha1.ThresholdStatus{
+				{Metric: "http_req_duration", Threshold: "p(95)<200"},
+			},
+			wantFailed:  []string{"http_req_duration: p(95)<200"},
+			wantOutcome: []*bool{ptrTo(false)},
+		},
+		{
+			name: "abort on fail",
+			thresholds: []v1alpha1.ThresholdStatus{
+				{Metric: "http_req_duration", Threshold: "p(95)<200", AbortOnFail: true},
+			},
+			wantFailed:  []string{"http_req_duration: p(95)<200"},
+			wantAbort:   true,
+			wantOutcome: []*bool{ptrTo(false)},
+		},
+		{
+			name: "abort on fail within grace period",
+			thresholds: []v1alpha1.ThresholdStatus{
+				{Metric: "http_req_duration", Threshold: "p(95)<200", AbortOnFail: true, AbortGracePeriod: grace},
+			},
+			running:     30 * time.Second,
+			wantFailed:  []string{"http_req_duration: p(95)<200"},
+			wantOutcome: []*bool{ptrTo(false)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			failed, unknown, abort := evaluateThresholds(tt.thresholds, summaries, tt.running)
+			assert.Equal(t, tt.wantFailed, failed)
+			assert.Equal(t, tt.wantUnknown, unknown)
+			assert.Equal(t, tt.wantAbort, abort)
+
+			for i, th := range tt.thresholds {
+				assert.Equal(t, tt.wantOutcome[i], th.Passed, th.Threshold)
+			}
+		})
+	}
+}
+
+func ptrTo(b bool) *bool {
+	return &b
+}
@@ -0,0 +1,143 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/grafana/k6-operator/api/v1alpha1"
+	"github.com/grafana/k6-operator/pkg/cloud"
+	"go.k6.io/k6/v2/cloudapi"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Reasons of TestRunFailed condition and of the corresponding Events,
+// in case one of .spec.timeouts is exceeded.
+const (
+	initializationTimeoutReason = "InitializationTimeout"
+	runnersReadyTimeoutReason   = "RunnersReadyTimeout"
+	startTimeoutReason          = "StartTimeout"
+	runTimeoutReason            = "RunTimeout"
+)
+
+// stageStartTime returns the time when the current stage has begun.
+// TestRuns created by older versions of k6-operator don't have
+// StageTransitionTime so fall back to the TestRunRunning condition,
+// which is set on initialization and on start of the test.
+func stageStartTime(k6 *v1alpha1.TestRun) time.Time {
+	if t := k6.GetStatus().StageTransitionTime; t != nil {
+		return t.Time
+	}
+	t, _ := v1alpha1.LastUpdate(k6, v1alpha1.TestRunRunning)
+	return t
+}
+
+func exceeds(limit *metav1.Duration, since time.Time, now time.Time) bool {
+	return limit != nil && limit.Duration > 0 && now.Sub(since) > limit.Duration
+}
+
+// exceededTimeout checks the timeouts that depend only on the stage of the TestRun.
+// Start timeout depends on the state of the starter Job so it is checked separately,
+// with startTimedOut.
+func exceededTimeout(k6 *v1alpha1.TestRun, now time.Time) (reason, msg string, exceeded bool) {
+	timeouts := k6.GetTimeouts()
+	if timeouts == nil {
+		return
+	}
+
+	switch k6.GetStatus().Stage {
+	case "initialization":
+		if exceeds(timeouts.Initialization, stageStartTime(k6), now) {
+			return initializationTimeoutReason,
+				fmt.Sprintf("initializer has not finished within %s: check the initializer job and pod", timeouts.Initialization.Duration),
+				true
+		}
+
+	case "created":
+		if exceeds(timeouts.RunnersReady, stageStartTime(k6), now) {
+			return runnersReadyTimeoutReason,
+				fmt.Sprintf("runner pods have not become ready within %s: check the runner jobs and pods", timeouts.RunnersReady.Duration),
+				true
+		}
+
+	case "started":
+		if exceeds(timeouts.Run, stageStartTime(k6), now) {
+			return runTimeoutReason,
+				fmt.Sprintf("test run has not finished within %s", timeouts.Run.Duration),
+				true
+		}
+	}
+
+	return
+}
+
+// startTimedOut checks if the starter Job has failed to complete within .spec.timeouts.start.
+func startTimedOut(ctx context.Context, k6 *v1alpha1.TestRun, c client.Client, now time.Time) (msg string, exceeded bool, err error) {
+	timeouts := k6.GetTimeouts()
+	if timeouts == nil || !exceeds(timeouts.Start, stageStartTime(k6), now) {
+		return
+	}
+
+	starter := &batchv1.Job{}
+	err = c.Get(ctx, types.NamespacedName{
+		Name:      fmt.Sprintf("%s-starter", k6.NamespacedName().Name),
+		Namespace: k6.NamespacedName().Namespace,
+	}, starter)
+	if err != nil && !k8sErrors.IsNotFound(err) {
+		return
+	}
+
+	if err == nil && starter.Status.Succeeded > 0 {
+		return "", false, nil
+	}
+
+	return fmt.Sprintf("starter job has not completed within %s: check the starter job and pod", timeouts.Start.Duration), true, nil
+}
+
+// failTestRun moves the TestRun to the error stage with the given result,
+// and with TestRunFailed condition describing the reason. All runner Jobs are deleted.
+func (r *TestRunReconciler) failTestRun(ctx context.Context, log logr.Logger, k6 *v1alpha1.TestRun, cloudClient *cloudapi.Client, result v1alpha1.TestRunResult, reason, msg string) (ctrl.Result, error) {
+	log.Info(fmt.Sprintf("Test run has failed: %s", msg), "reason", reason)
+
+	r.Recorder.Eventf(k6, nil, corev1.EventTypeWarning, reason, "Failing", "%s", msg)
+
+	if isCloudTestRun(k6) {
+		errorCode := cloud.K6OperatorStartError
+		if k6.GetStatus().Stage == "started" {
+			errorCode = cloud.K6OperatorRunnerError
+		}
+		events := cloud.ErrorEvent(errorCode).
+			WithDetail(msg).
+			WithAbort()
+		cloud.SendTestRunEvents(cloudClient, k6.NamespacedName(), k6.TestRunID(), log, events)
+	}
+
+	if k6.GetStatus().Stage == "initialization" {
+		_ = KillInitializer(ctx, log, k6, r)
+	}
+
+	if _, err := KillJobs(ctx, log, k6, r); err != nil {
+		return ctrl.Result{RequeueAfter: time.Second}, err
+	}
+
+	k6.GetStatus().Result = result
+	v1alpha1.SetCondition(k6, v1alpha1.TestRunFailed, metav1.ConditionTrue, reason, msg)
+	v1alpha1.UpdateCondition(k6, v1alpha1.TestRunSucceeded, metav1.ConditionFalse)
+	if v1alpha1.IsTrue(k6, v1alpha1.TestRunRunning) {
+		v1alpha1.UpdateCondition(k6, v1alpha1.TestRunRunning, metav1.ConditionFalse)
+	}
+
+	log.Info("Changing stage of TestRun status to error")
+	k6.GetStatus().Stage = "error"
+	k6.GetStatus().LastError = msg
+
+	_, err := r.UpdateStatus(ctx, k6, log)
+	return ctrl.Result{}, err
+}
@@ -0,0 +1,135 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/grafana/k6-operator/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	batchv1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func testRunInStage(stage v1alpha1.Stage, since time.Time, timeouts *v1alpha1.TestRunTimeouts) *v1alpha1.TestRun {
+	t := metav1.NewTime(since)
+	return &v1alpha1.TestRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "default",
+		},
+		Spec: v1alpha1.TestRunSpec{
+			Timeouts: timeouts,
+		},
+		Status: v1alpha1.TestRunStatus{
+			Stage:               stage,
+			StageTransitionTime: &t,
+		},
+	}
+}
+
+func Test_exceededTimeout(t *testing.T) {
+	now := time.Now()
+	minute := &metav1.Duration{Duration: time.Minute}
+
+	tests := []struct {
+		name           string
+		k6             *v1alpha1.TestRun
+		expectedReason string
+	}{
+		{
+			name: "no timeouts",
+			k6:   testRunInStage("initialization", now.Add(-time.Hour), nil),
+		},
+		{
+			name: "initialization within the limit",
+			k6: testRunInStage("initialization", now.Add(-30*time.Second),
+				&v1alpha1.TestRunTimeouts{Initialization: minute}),
+		},
+		{
+			name: "initialization exceeded",
+			k6: testRunInStage("initialization", now.Add(-2*time.Minute),
+				&v1alpha1.TestRunTimeouts{Initialization: minute}),
+			expectedReason: initializationTimeoutReason,
+		},
+		{
+			name: "zero timeout means no limit",
+			k6: testRunInStage("initialization", now.Add(-2*time.Minute),
+				&v1alpha1.TestRunTimeouts{Initialization: &metav1.Duration{}}),
+		},
+		{
+			name: "runners ready exceeded",
+			k6: testRunInStage("created", now.Add(-2*time.Minute),
+				&v1alpha1.TestRunTimeouts{RunnersReady: minute}),
+			expectedReason: runnersReadyTimeoutReason,
+		},
+		{
+			name: "timeout of another stage is ignored",
+			k6: testRunInStage("created", now.Add(-2*time.Minute),
+				&v1alpha1.TestRunTimeouts{Initialization: minute, Run: minute}),
+		},
+		{
+			name: "run exceeded",
+			k6: testRunInStage("started", now.Add(-2*time.Minute),
+				&v1alpha1.TestRunTimeouts{Run: minute}),
+			expectedReason: runTimeoutReason,
+		},
+		{
+			name: "finished test run is never timed out",
+			k6: testRunInStage("finished", now.Add(-2*time.Minute),
+				&v1alpha1.TestRunTimeouts{Run: minute}),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reason, msg, exceeded := exceededTimeout(tt.k6, now)
+
+			assert.Equal(t, tt.expectedReason, reason)
+			assert.Equal(t, len(tt.expectedReason) > 0, exceeded)
+			assert.Equal(t, exceeded, len(msg) > 0)
+		})
+	}
+}
+
+func Test_startTimedOut(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, batchv1.AddToScheme(scheme))
+
+	now := time.Now()
+	timeouts := &v1alpha1.TestRunTimeouts{Start: &metav1.Duration{Duration: time.Minute}}
+
+	t.Run("within the limit", func(t *testing.T) {
+		k6 := testRunInStage("started", now.Add(-30*time.Second), timeouts)
+		k8sClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+
+		_, exceeded, err := startTimedOut(context.Background(), k6, k8sClient, now)
+		require.NoError(t, err)
+		assert.False(t, exceeded)
+	})
+
+	t.Run("starter job is missing", func(t *testing.T) {
+		k6 := testRunInStage("started", now.Add(-2*time.Minute), timeouts)
+		k8sClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+
+		_, exceeded, err := startTimedOut(context.Background(), k6, k8sClient, now)
+		require.NoError(t, err)
+		assert.True(t, exceeded)
+	})
+
+	t.Run("starter job has succeeded", func(t *testing.T) {
+		k6 := testRunInStage("started", now.Add(-2*time.Minute), timeouts)
+		starter := &batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-starter", Namespace: "default"},
+			Status:     batchv1.JobStatus{Succeeded: 1},
+		}
+		k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(starter).Build()
+
+		_, exceeded, err := startTimedOut(context.Background(), k6, k8sClient, now)
+		require.NoError(t, err)
+		assert.False(t, exceeded)
+	})
+}
@@ -0,0 +1,71 @@
+package controllers
+
+import (
+	"time"
+
+	"github.com/grafana/k6-operator/api/v1alpha1"
+	"github.com/grafana/k6-operator/pkg/cloud"
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var (
+	testRunStage = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "k6_operator_testrun_stage",
+		Help: "Current stage of the TestRun: the series with the current stage has the value of 1.",
+	}, []string{"namespace", "testrun", "stage"})
+
+	testRunStageDuration = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "k6_operator_testrun_stage_duration_seconds",
+		Help: "Time spent by the TestRun in a stage, set once the TestRun has left that stage.",
+	}, []string{"namespace", "testrun", "stage"})
+
+	runnerFailures = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "k6_operator_runner_failures_total",
+		Help: "Number of runner Jobs which have crashed, were killed or could not run k6.",
+	}, []string{"namespace", "testrun"})
+
+	initializerFailures = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "k6_operator_initializer_failures_total",
+		Help: "Number of failures of the initializer Job, e.g. because of an invalid script.",
+	}, []string{"namespace", "testrun"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(testRunStage, testRunStageDuration, runnerFailures, initializerFailures)
+}
+
+// recordStage sets the stage gauge to the current stage of the TestRun.
+func recordStage(k6 *v1alpha1.TestRun) {
+	nn := k6.NamespacedName()
+	testRunStage.DeletePartialMatch(prometheus.Labels{"namespace": nn.Namespace, "testrun": nn.Name})
+	testRunStage.WithLabelValues(nn.Namespace, nn.Name, string(k6.GetStatus().Stage)).Set(1)
+}
+
+// recordStageTransition records the time spent in the previous stage
+// once the TestRun has moved on to the next one.
+func recordStageTransition(previous, current *v1alpha1.TestRun, now time.Time) {
+	if previous.GetStatus().Stage == current.GetStatus().Stage {
+		return
+	}
+
+	since := previous.GetCreationTimestamp().Time
+	if t := previous.GetStatus().StageTransitionTime; t != nil {
+		since = t.Time
+	}
+
+	nn := current.NamespacedName()
+	testRunStageDuration.WithLabelValues(nn.Namespace, nn.Name, string(previous.GetStatus().Stage)).Set(now.Sub(since).Seconds())
+	recordStage(current)
+}
+
+// deleteTestRunMetrics removes all series of a deleted TestRun.
+func deleteTestRunMetrics(namespace, name string) {
+	labels := prometheus.Labels{"namespace": namespace, "testrun": name}
+	testRunStage.DeletePartialMatch(labels)
+	testRunStageDuration.DeletePartialMatch(labels)
+	runnerFailures.DeletePartialMatch(labels)
+	initializerFailures.DeletePartialMatch(labels)
+	cloud.DeleteTestRunMetrics(types.NamespacedName{Namespace: namespace, Name: name})
+}
@@ -0,0 +1,35 @@
+package controllers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/grafana/k6-operator/api/v1alpha1"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_recordStageTransition(t *testing.T) {
+	now := time.Now()
+	k6 := &v1alpha1.TestRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "metrics", Namespace: "default"},
+		Status: v1alpha1.TestRunStatus{
+			Stage:               "created",
+			StageTransitionTime: &metav1.Time{Time: now.Add(-time.Minute)},
+		},
+	}
+	recordStage(k6)
+
+	started := k6.DeepCopy()
+	started.Status.Stage = "started"
+	recordStageTransition(k6, started, now)
+
+	assert.Equal(t, 60.0, testutil.ToFloat64(testRunStageDuration.WithLabelValues("default", "metrics", "created")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(testRunStage.WithLabelValues("default", "metrics", "started")))
+	assert.False(t, testRunStage.DeleteLabelValues("default", "metrics", "created"), "previous stage must be removed")
+
+	deleteTestRunMetrics("default", "metrics")
+	assert.False(t, testRunStage.DeleteLabelValues("default", "metrics", "started"))
+	assert.False(t, testRunStageDuration.DeleteLabelValues("default", "metrics", "created"))
+}
@@ -0,0 +1,47 @@
+package controllers
+
+import (
+	"github.com/grafana/k6-operator/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// newOwnedTestRun builds a TestRun from the template, controlled by the owner,
+// e.g. a TestRunSchedule. The owner labels are added to the labels of the template.
+func newOwnedTestRun(owner client.Object, name string, template *v1alpha1.TestRunObjectTemplate, ownerLabels map[string]string, scheme *runtime.Scheme) (*v1alpha1.TestRun, error) {
+	labels := map[string]string{}
+	for k, v := range template.Metadata.Labels {
+		labels[k] = v
+	}
+	for k, v := range ownerLabels {
+		labels[k] = v
+	}
+
+	annotations := map[string]string{}
+	for k, v := range template.Metadata.Annotations {
+		annotations[k] = v
+	}
+
+	testRun := &v1alpha1.TestRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   owner.GetNamespace(),
+			Labels:      labels,
+			Annotations: annotations,
+		},
+		Spec: *template.Spec.DeepCopy(),
+	}
+
+	if err := controllerutil.SetControllerReference(owner, testRun, scheme); err != nil {
+		return nil, err
+	}
+	return testRun, nil
+}
+
+// isTestRunFinished checks if the test run has reached its final stage.
+func isTestRunFinished(k6 *v1alpha1.TestRun) bool {
+	stage := k6.GetStatus().Stage
+	return stage == "finished" || stage == "error"
+}
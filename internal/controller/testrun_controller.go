@@ -24,11 +24,13 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/events"
 
 	"github.com/go-logr/logr"
 	"github.com/grafana/k6-operator/api/v1alpha1"
 	"github.com/grafana/k6-operator/pkg/cloud"
+	"github.com/grafana/k6-operator/pkg/testrun"
 	k6types "github.com/grafana/k6-operator/pkg/types"
 	batchv1 "k8s.io/api/batch/v1"
 	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
@@ -52,17 +54,34 @@ type TestRunReconciler struct {
 	Log      logr.Logger
 	Scheme   *runtime.Scheme
 	Recorder events.EventRecorder
+
+	// Clientset is used for requests not supported by the controller-runtime
+	// client, like reading the logs of pods. It must use the config of the manager.
+	Clientset kubernetes.Interface
+
+	// RunnerTransport is used for requests to k6 REST API of the runners.
+	// If nil, the runners are reached directly via the pod network.
+	RunnerTransport testrun.Transport
+
+	// APIReader reads objects which may be outside of the watched namespaces,
+	// like scripts referenced from other namespaces. If nil, the client is used.
+	APIReader client.Reader
 }
 
 // Reconcile takes a K6 object and takes the appropriate action in the cluster
 // +kubebuilder:rbac:groups=k6.io,resources=testruns,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=k6.io,resources=testruns/status;testruns/finalizers,verbs=get;update;patch
+// +kubebuilder:rbac:groups=k6.io,resources=scriptgrants,verbs=get;list;watch
+// +kubebuilder:rbac:groups=k6.io,resources=testruntemplates;clustertestruntemplates,verbs=get;list;watch
 // +kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=core,resources=pods;pods/log,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=pods/proxy,verbs=get;create;update;patch
 // +kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=coordination.k8s.io,resources=leases,verbs=get;list;create;update
 // +kubebuilder:rbac:groups="",resources=services,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=persistentvolumeclaims,verbs=get;list;watch;create;delete
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=events.k8s.io,resources=events,verbs=create;patch
 
 func (r *TestRunReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
@@ -75,14 +94,32 @@ func (r *TestRunReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 	if err != nil {
 		if k8sErrors.IsNotFound(err) {
 			log.Info("Request deleted. Nothing to reconcile.")
+			deleteTestRunMetrics(req.Namespace, req.Name)
 			return ctrl.Result{}, nil
 		}
 		log.Error(err, "Could not fetch request")
 		return ctrl.Result{Requeue: true}, err
 	}
 
-	if k6.Spec.Parallelism < 1 {
-		err = fmt.Errorf("parallelism of TestRun cannot be less than 1; provided value is %d", k6.Spec.Parallelism)
+	recordStage(k6)
+
+	// The template must be resolved before anything else, since
+	// any field of the spec, e.g. the token, can come from it.
+	if k6.Spec.TemplateRef != nil && k6.Status.ResolvedSpec == nil && len(k6.GetStatus().Stage) == 0 && k6.DeletionTimestamp.IsZero() {
+		if err := ResolveTemplate(ctx, log, k6, r); err != nil {
+			var refErr *ReferenceError
+			if errors.As(err, &refErr) {
+				return r.failTestRun(ctx, log, k6, nil, v1alpha1.ResultScriptError, refErr.Reason, refErr.Message)
+			}
+			return ctrl.Result{}, err
+		}
+		if _, err := r.UpdateStatus(ctx, k6, log); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	if k6.GetSpec().Parallelism < 1 && !k6.IsFailed() {
+		err = fmt.Errorf("parallelism of TestRun cannot be less than 1; provided value is %d", k6.GetSpec().Parallelism)
 		log.Error(err, "Stopping reconciliation.")
 		return ctrl.Result{}, err
 	}
@@ -99,6 +136,17 @@ func (r *TestRunReconciler) reconcile(ctx context.Context, req ctrl.Request, log
 		err         error
 		cloudClient *cloudapi.Client
 	)
+
+	if !k6.DeletionTimestamp.IsZero() {
+		return FinalizeTestRun(ctx, log, k6, r, time.Now())
+	}
+
+	if needsFinalizer(k6) {
+		if added, err := AddFinalizer(ctx, log, k6, r); err != nil || added {
+			return ctrl.Result{Requeue: true}, err
+		}
+	}
+
 	if isCloudTestRun(k6) {
 		// bootstrap the client
 		var found bool
@@ -115,6 +163,14 @@ func (r *TestRunReconciler) reconcile(ctx context.Context, req ctrl.Request, log
 
 	log.Info(fmt.Sprintf("Reconcile(); stage = %s", k6.GetStatus().Stage))
 
+	runnersChanged := UpdateRunnersStatus(ctx, log, k6, r)
+	if runnersChanged || k6.GetStatus().ObservedGeneration != k6.GetGeneration() {
+		k6.GetStatus().ObservedGeneration = k6.GetGeneration()
+		if _, err := r.UpdateStatus(ctx, k6, log); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
 	// Decision making here is now a mix between stages and conditions.
 	// TODO: refactor further.
 
@@ -126,6 +182,17 @@ func (r *TestRunReconciler) reconcile(ctx context.Context, req ctrl.Request, log
 		}
 	}
 
+	if k6.IsStopRequested() {
+		switch k6.GetStatus().Stage {
+		case "", "initialization", "initialized", "created", "started":
+			return StopJobsByUser(ctx, log, k6, r)
+		}
+	}
+
+	if reason, msg, exceeded := exceededTimeout(k6, time.Now()); exceeded {
+		return r.failTestRun(ctx, log, k6, cloudClient, v1alpha1.ResultRunnerFailed, reason, msg)
+	}
+
 	switch k6.GetStatus().Stage {
 	case "":
 		log.Info("Initialize test")
@@ -140,6 +207,7 @@ func (r *TestRunReconciler) reconcile(ctx context.Context, req ctrl.Request, log
 			log.Error(err, "Invalid TestRun")
 			log.Info("Changing stage of TestRun status to error")
 			k6.GetStatus().Stage = "error"
+			k6.GetStatus().LastError = err.Error()
 			_, err := r.UpdateStatus(ctx, k6, log)
 			return ctrl.Result{}, err
 		}
@@ -150,10 +218,27 @@ func (r *TestRunReconciler) reconcile(ctx context.Context, req ctrl.Request, log
 			return ctrl.Result{}, err
 		}
 
+		// A script from another namespace must be copied before the jobs
+		// are created, since they can mount only ConfigMaps of their namespace.
+		if k6.IsReferencingScript() {
+			if err := CopyScript(ctx, log, k6, r); err != nil {
+				var refErr *ReferenceError
+				if errors.As(err, &refErr) {
+					return r.failTestRun(ctx, log, k6, cloudClient, v1alpha1.ResultScriptError, refErr.Reason, refErr.Message)
+				}
+				return ctrl.Result{}, err
+			}
+		}
+
 		// Skip initializer if disabled, unless --out cloud is present
 		// (cloud output tests require initializer to run k6 inspect)
+		// or runners need the archive of the initializer
+		// or the operator evaluates thresholds read by k6 inspect
+		// or the script is fetched by an init container, whose failures
+		// would otherwise leave the runners failing without a condition
 		cli, _ := k6types.ParseCLI(k6.GetSpec().Argv())
-		if !cli.HasCloudOut && k6.IsInitializerDisabled() {
+		if !cli.HasCloudOut && !k6.IsReusingArchive() && !k6.IsEvaluatingThresholds() && !k6.IsFetchingScript() &&
+			k6.IsInitializerDisabled() {
 			log.Info("Initializer is disabled, skipping initialization step")
 
 			log.Info("Changing stage of TestRun status to initialized")
@@ -192,7 +277,7 @@ func (r *TestRunReconciler) reconcile(ctx context.Context, req ctrl.Request, log
 						events := cloud.ErrorEvent(cloud.K6OperatorStartError).
 							WithDetail(msg).
 							WithAbort()
-						cloud.SendTestRunEvents(cloudClient, k6.TestRunID(), log, events)
+						cloud.SendTestRunEvents(cloudClient, k6.NamespacedName(), k6.TestRunID(), log, events)
 					}
 				}
 			}
@@ -250,32 +335,97 @@ func (r *TestRunReconciler) reconcile(ctx context.Context, req ctrl.Request, log
 			return ctrl.Result{}, nil
 		}
 
-		if v1alpha1.IsTrue(k6, v1alpha1.CloudPLZTestRun) {
-			runningTime, _ := v1alpha1.LastUpdate(k6, v1alpha1.TestRunRunning)
+		if msg, exceeded, err := startTimedOut(ctx, k6, r.Client, time.Now()); err != nil {
+			log.Error(err, "Could not get the starter job")
+		} else if exceeded {
+			return r.failTestRun(ctx, log, k6, cloudClient, v1alpha1.ResultRunnerFailed, startTimeoutReason, msg)
+		}
+
+		if k6.IsPauseChanged() {
+			return PauseJobs(ctx, log, k6, r)
+		}
+
+		if k6.IsEvaluatingThresholds() && v1alpha1.IsTrue(k6, v1alpha1.TestRunRunning) {
+			if abort, err := EvaluateThresholds(ctx, log, k6, r); err == nil {
+				if abort {
+					log.Info("A threshold with abortOnFail has failed: stopping the test.")
+					r.Recorder.Eventf(k6, nil, corev1.EventTypeWarning, "ThresholdsAborted", "Stopping",
+						"a threshold with abortOnFail has failed: stopping the test")
+					if err := createStopJob(ctx, log, k6, r); err != nil {
+						return ctrl.Result{RequeueAfter: time.Second}, nil
+					}
+				}
+				if _, err := r.UpdateStatus(ctx, k6, log); err != nil {
+					return ctrl.Result{}, err
+				}
+			}
+		}
+
+		if k6.IsLingering() {
+			teardownPending := k6.IsSetupTeardownOnce() && v1alpha1.IsFalse(k6, v1alpha1.TeardownExecuted)
+			summaryPending := k6.GetSpec().CollectSummary && len(k6.GetStatus().SummaryConfigMap) == 0
+
+			if teardownPending || summaryPending {
+				runningTime, _ := v1alpha1.LastUpdate(k6, v1alpha1.TestRunRunning)
 
-			if v1alpha1.IsFalse(k6, v1alpha1.TeardownExecuted) {
 				var allJobsStopped bool
 				// TODO: figure out baseline time
 				if time.Since(runningTime) > time.Second*30 {
 					allJobsStopped = StoppedJobs(ctx, log, k6, r)
 				}
 
+				if !v1alpha1.IsFalse(k6, v1alpha1.CloudTestRunAborted) || !allJobsStopped {
+					// Test runs can take a long time and usually they aren't supposed
+					// to be too quick. So check in only periodically.
+					return ctrl.Result{RequeueAfter: time.Second * 15}, nil
+				}
+
 				// The test run reached a regular stop in execution so execute teardown
-				if v1alpha1.IsFalse(k6, v1alpha1.CloudTestRunAborted) && allJobsStopped {
-					hostnames, err := r.hostnames(ctx, log, false, k6.ListOptions())
+				if teardownPending {
+					runners, err := r.runners(ctx, log, k6, true)
 					if err != nil {
 						return ctrl.Result{}, nil
 					}
-					runTeardown(ctx, hostnames, log)
+					if err := runTeardown(ctx, r.transport(), runners, log); err != nil {
+						msg := fmt.Sprintf("teardown function failed: %v", err)
+						v1alpha1.SetCondition(k6, v1alpha1.TeardownSucceeded, metav1.ConditionFalse, "TeardownFailed", msg)
+						r.Recorder.Eventf(k6, nil, corev1.EventTypeWarning, "TeardownFailed", "RunTeardown", "%s", msg)
+					} else {
+						v1alpha1.UpdateCondition(k6, v1alpha1.TeardownSucceeded, metav1.ConditionTrue)
+					}
 					v1alpha1.UpdateCondition(k6, v1alpha1.TeardownExecuted, metav1.ConditionTrue)
-
-					_, err = r.UpdateStatus(ctx, k6, log)
-					return ctrl.Result{}, err
 					// NOTE: we proceed here regardless whether teardown() is successful or not
-				} else {
-					// Test runs can take a long time and usually they aren't supposed
-					// to be too quick. So check in only periodically.
-					return ctrl.Result{RequeueAfter: time.Second * 15}, nil
+				}
+
+				// Summaries are collected after teardown so that they include its metrics.
+				res := ctrl.Result{}
+				if summaryPending {
+					if err := CollectSummary(ctx, log, k6, r); err != nil {
+						res = ctrl.Result{RequeueAfter: time.Second * 5}
+					}
+				}
+
+				_, err := r.UpdateStatus(ctx, k6, log)
+				return res, err
+			}
+
+			// Outside of PLZ, nobody else is going to remove the runners which
+			// linger after the end of the test. They exit only on a signal, so
+			// the result is taken from their REST API before they are deleted.
+			if !v1alpha1.IsTrue(k6, v1alpha1.CloudPLZTestRun) {
+				if len(k6.GetStatus().Result) == 0 {
+					// The final evaluation of thresholds, after the end of the test.
+					if k6.IsEvaluatingThresholds() {
+						if _, err := EvaluateThresholds(ctx, log, k6, r); err != nil {
+							return ctrl.Result{RequeueAfter: time.Second * 5}, nil
+						}
+					}
+					if err := SetLingeringResult(ctx, log, k6, r); err != nil {
+						return ctrl.Result{RequeueAfter: time.Second * 5}, nil
+					}
+				}
+				if allDeleted, err := KillJobs(ctx, log, k6, r); err != nil || !allDeleted {
+					return ctrl.Result{RequeueAfter: time.Second}, err
 				}
 			}
 		} else if !FinishJobs(ctx, log, k6, r, cloudClient) {
@@ -317,6 +467,25 @@ func (r *TestRunReconciler) reconcile(ctx context.Context, req ctrl.Request, log
 		return ctrl.Result{}, nil
 
 	case "stopped":
+		if v1alpha1.IsTrue(k6, v1alpha1.TestRunStoppedByUser) && !RunnersExited(ctx, log, k6, r) {
+			// Lingering runners do not exit after the stop call, so they
+			// are deleted once they have stopped execution.
+			if k6.IsLingering() && !v1alpha1.IsTrue(k6, v1alpha1.CloudPLZTestRun) && StoppedJobs(ctx, log, k6, r) {
+				if _, err := KillJobs(ctx, log, k6, r); err != nil {
+					return ctrl.Result{RequeueAfter: time.Second}, err
+				}
+			}
+			// Runners need some time to finish gracefully after the stop call.
+			return ctrl.Result{RequeueAfter: time.Second * 5}, nil
+		}
+
+		// The result of PLZ test runs is determined by k6 Cloud.
+		if len(k6.GetStatus().Result) == 0 && !v1alpha1.IsTrue(k6, v1alpha1.CloudPLZTestRun) {
+			if err = SetResult(ctx, log, k6, r); err != nil {
+				return ctrl.Result{RequeueAfter: time.Second}, nil
+			}
+		}
+
 		if v1alpha1.IsTrue(k6, v1alpha1.CloudPLZTestRun) && v1alpha1.IsTrue(k6, v1alpha1.CloudTestRunAborted) {
 			// This is a "forced" abort of the PLZ test run.
 			// Wait until all the test runs are stopped, kill jobs and proceed.
@@ -337,7 +506,10 @@ func (r *TestRunReconciler) reconcile(ctx context.Context, req ctrl.Request, log
 		}
 
 		// If this is a cloud test run in any mode, try to finalize it.
+		// It might have been stopped by user before k6 Cloud test run
+		// was created: then there is nothing to finalize.
 		if v1alpha1.IsTrue(k6, v1alpha1.CloudTestRun) &&
+			v1alpha1.IsTrue(k6, v1alpha1.CloudTestRunCreated) &&
 			v1alpha1.IsFalse(k6, v1alpha1.CloudTestRunFinalized) {
 
 			// If TestRunRunning has just been updated, wait for a bit before
@@ -347,8 +519,10 @@ func (r *TestRunReconciler) reconcile(ctx context.Context, req ctrl.Request, log
 				return ctrl.Result{RequeueAfter: time.Second * 2}, nil
 			}
 
-			if err = cloud.FinishTestRun(cloudClient, k6.GetStatus().TestRunID); err != nil {
+			if err = cloud.FinishTestRun(cloudClient, k6.NamespacedName(), k6.GetStatus().TestRunID); err != nil {
 				log.Error(err, "Failed to finalize the test run with cloud output")
+				r.Recorder.Eventf(k6, nil, corev1.EventTypeWarning, "CloudFinalizeFailed", "FinalizeCloudTestRun",
+					"failed to finalize cloud test run %s: %v", k6.GetStatus().TestRunID, err)
 				return ctrl.Result{}, nil
 			} else {
 				log.Info(fmt.Sprintf("Cloud test run %s was finalized successfully", k6.GetStatus().TestRunID))
@@ -368,10 +542,17 @@ func (r *TestRunReconciler) reconcile(ctx context.Context, req ctrl.Request, log
 		return ctrl.Result{RequeueAfter: time.Second}, nil
 
 	case "error", "finished":
+		// there is nothing left to finalize on deletion
+		if err := RemoveFinalizer(ctx, log, k6, r); err != nil {
+			return ctrl.Result{}, err
+		}
+
 		// delete if configured
-		if k6.GetSpec().Cleanup == "post" {
+		if k6.GetCleanup() == "post" {
 			log.Info("Cleaning up all resources")
 			_ = r.Delete(ctx, k6)
+		} else if k6.GetCleanupPolicy() != nil {
+			return ApplyCleanupPolicy(ctx, log, k6, r, time.Now())
 		}
 		// notify if configured
 		return ctrl.Result{}, nil
@@ -451,6 +632,9 @@ func (r *TestRunReconciler) UpdateStatus(ctx context.Context, k6 *v1alpha1.TestR
 		return false, err
 	}
 
+	recordStageTransition(cleanObj.(*v1alpha1.TestRun), k6, time.Now())
+	r.emitStageEvent(cleanObj.(*v1alpha1.TestRun), k6)
+
 	return true, nil
 }
 
@@ -463,7 +647,7 @@ func (r *TestRunReconciler) ShouldAbort(ctx context.Context, k6 *v1alpha1.TestRu
 		return false
 	}
 
-	status, err := cloud.GetTestRunState(cloudClient, k6.TestRunID(), log)
+	status, err := cloud.GetTestRunState(cloudClient, k6.NamespacedName(), k6.TestRunID(), log)
 	if err != nil {
 		return false
 	}
@@ -0,0 +1,301 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/robfig/cron/v3"
+	corev1 "k8s.io/api/core/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/events"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+
+	"github.com/grafana/k6-operator/api/v1alpha1"
+)
+
+// TestRunScheduleReconciler reconciles a TestRunSchedule object
+type TestRunScheduleReconciler struct {
+	client.Client
+	Log      logr.Logger
+	Scheme   *runtime.Scheme
+	Recorder events.EventRecorder
+
+	// Now returns the current time; it is replaced in tests.
+	Now func() time.Time
+}
+
+//+kubebuilder:rbac:groups=k6.io,resources=testrunschedules,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=k6.io,resources=testrunschedules/status,verbs=get;update;patch
+
+// Reconcile creates TestRuns of a TestRunSchedule when they are due, keeps
+// the history of finished TestRuns within the limits and updates the status.
+func (r *TestRunScheduleReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.Log.WithValues("namespace", req.Namespace, "name", req.Name, "reconcileID", controller.ReconcileIDFromContext(ctx))
+
+	schedule := &v1alpha1.TestRunSchedule{}
+	if err := r.Get(ctx, req.NamespacedName, schedule); err != nil {
+		if k8sErrors.IsNotFound(err) {
+			log.Info("Request deleted. Nothing to reconcile.")
+			return ctrl.Result{}, nil
+		}
+		log.Error(err, "Could not fetch request")
+		return ctrl.Result{Requeue: true}, err
+	}
+	if !schedule.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	cleanObj := schedule.DeepCopy()
+
+	active, err := r.syncHistory(ctx, log, schedule)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	result, err := r.scheduleNext(ctx, log, schedule, active)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if err := r.Status().Patch(ctx, schedule, client.MergeFrom(cleanObj)); err != nil {
+		log.Error(err, "Could not update status of TestRunSchedule")
+		return ctrl.Result{}, err
+	}
+
+	return result, nil
+}
+
+// syncHistory records the active TestRuns and the last finished one in the
+// status and deletes the finished TestRuns beyond the history limits.
+// It returns the active TestRuns.
+func (r *TestRunScheduleReconciler) syncHistory(ctx context.Context, log logr.Logger, schedule *v1alpha1.TestRunSchedule) ([]*v1alpha1.TestRun, error) {
+	list := &v1alpha1.TestRunList{}
+	if err := r.List(ctx, list,
+		client.InNamespace(schedule.Namespace),
+		client.MatchingLabels{v1alpha1.ScheduleLabel: schedule.Name}); err != nil {
+		log.Error(err, "Could not list TestRuns of the schedule")
+		return nil, err
+	}
+
+	var active, succeeded, failed []*v1alpha1.TestRun
+	for i := range list.Items {
+		testRun := &list.Items[i]
+		if !metav1.IsControlledBy(testRun, schedule) {
+			continue
+		}
+		switch {
+		case !isTestRunFinished(testRun):
+			active = append(active, testRun)
+		case testRun.IsFailed():
+			failed = append(failed, testRun)
+		default:
+			succeeded = append(succeeded, testRun)
+		}
+	}
+
+	schedule.Status.Active = nil
+	for _, testRun := range active {
+		schedule.Status.Active = append(schedule.Status.Active, testRun.Name)
+	}
+	sort.Strings(schedule.Status.Active)
+
+	// TestRuns are named by the time they were scheduled at, so the last
+	// finished one is the one with the latest creation time.
+	finished := append(append([]*v1alpha1.TestRun{}, succeeded...), failed...)
+	sortByCreation(finished)
+	if len(finished) > 0 {
+		last := finished[len(finished)-1]
+		if schedule.Status.LastTestRun != last.Name {
+			schedule.Status.LastTestRun = last.Name
+			schedule.Status.LastResult = last.Status.Result
+			completed := metav1.NewTime(finishedAt(last))
+			schedule.Status.LastCompletionTime = &completed
+		}
+	}
+
+	if err := r.prune(ctx, log, succeeded, schedule.Spec.SuccessfulRunsHistoryLimit); err != nil {
+		return nil, err
+	}
+	if err := r.prune(ctx, log, failed, schedule.Spec.FailedRunsHistoryLimit); err != nil {
+		return nil, err
+	}
+
+	return active, nil
+}
+
+// prune deletes the oldest TestRuns so that no more than limit of them remain.
+func (r *TestRunScheduleReconciler) prune(ctx context.Context, log logr.Logger, testRuns []*v1alpha1.TestRun, limit *int32) error {
+	if limit == nil || len(testRuns) <= int(*limit) {
+		return nil
+	}
+
+	sortByCreation(testRuns)
+	for _, testRun := range testRuns[:len(testRuns)-int(*limit)] {
+		log.Info(fmt.Sprintf("Deleting TestRun %s beyond the history limit", testRun.Name))
+		if err := r.Delete(ctx, testRun, client.PropagationPolicy(metav1.DeletePropagationBackground)); client.IgnoreNotFound(err) != nil {
+			log.Error(err, "Could not delete TestRun beyond the history limit")
+			return err
+		}
+	}
+	return nil
+}
+
+// scheduleNext creates the TestRun if one is due and returns when to
+// reconcile again for the next one.
+func (r *TestRunScheduleReconciler) scheduleNext(ctx context.Context, log logr.Logger, schedule *v1alpha1.TestRunSchedule, active []*v1alpha1.TestRun) (ctrl.Result, error) {
+	if schedule.Spec.Suspend {
+		log.Info("Schedule is suspended.")
+		return ctrl.Result{}, nil
+	}
+
+	sched, err := cron.ParseStandard(schedule.Spec.Schedule)
+	if err != nil {
+		return r.invalidSchedule(schedule, "InvalidSchedule", err), nil
+	}
+	loc := time.UTC
+	if len(schedule.Spec.TimeZone) > 0 {
+		if loc, err = time.LoadLocation(schedule.Spec.TimeZone); err != nil {
+			return r.invalidSchedule(schedule, "InvalidTimeZone", err), nil
+		}
+	}
+	schedule.Status.LastError = ""
+
+	now := r.now().In(loc)
+	scheduled, next := dueTime(schedule, sched, now)
+
+	var result ctrl.Result
+	if !next.IsZero() {
+		result.RequeueAfter = next.Sub(now)
+	}
+	if scheduled.IsZero() {
+		return result, nil
+	}
+
+	scheduledTime := metav1.NewTime(scheduled)
+	if deadline := schedule.Spec.StartingDeadlineSeconds; deadline != nil &&
+		now.Sub(scheduled) > time.Duration(*deadline)*time.Second {
+		log.Info(fmt.Sprintf("Missed the starting deadline of TestRun scheduled at %s", scheduled))
+		r.Recorder.Eventf(schedule, nil, corev1.EventTypeWarning, "MissedSchedule", "Schedule",
+			"missed the starting deadline of TestRun scheduled at %s", scheduled)
+		schedule.Status.LastScheduleTime = &scheduledTime
+		return result, nil
+	}
+
+	switch schedule.Spec.ConcurrencyPolicy {
+	case v1alpha1.ForbidConcurrent:
+		if len(active) > 0 {
+			log.Info(fmt.Sprintf("Skipping TestRun scheduled at %s: %d TestRun(s) still running", scheduled, len(active)))
+			r.Recorder.Eventf(schedule, nil, corev1.EventTypeNormal, "SkippedSchedule", "Schedule",
+				"skipped TestRun scheduled at %s: the previous TestRun is still running", scheduled)
+			schedule.Status.LastScheduleTime = &scheduledTime
+			return result, nil
+		}
+	case v1alpha1.ReplaceConcurrent:
+		for _, testRun := range active {
+			log.Info(fmt.Sprintf("Deleting running TestRun %s to replace it", testRun.Name))
+			if err := r.Delete(ctx, testRun, client.PropagationPolicy(metav1.DeletePropagationBackground)); client.IgnoreNotFound(err) != nil {
+				log.Error(err, "Could not delete running TestRun")
+				return ctrl.Result{}, err
+			}
+		}
+		schedule.Status.Active = nil
+	}
+
+	testRun, err := newScheduledTestRun(schedule, scheduledTime, r.Scheme)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if err := r.Create(ctx, testRun); err != nil && !k8sErrors.IsAlreadyExists(err) {
+		log.Error(err, "Could not create TestRun")
+		r.Recorder.Eventf(schedule, nil, corev1.EventTypeWarning, "TestRunCreationFailed", "Schedule",
+			"could not create TestRun %s: %v", testRun.Name, err)
+		return ctrl.Result{}, err
+	}
+
+	log.Info(fmt.Sprintf("Created TestRun %s scheduled at %s", testRun.Name, scheduled))
+	r.Recorder.Eventf(schedule, testRun, corev1.EventTypeNormal, "TestRunCreated", "Schedule",
+		"created TestRun %s", testRun.Name)
+
+	schedule.Status.LastScheduleTime = &scheduledTime
+	schedule.Status.Active = append(schedule.Status.Active, testRun.Name)
+	return result, nil
+}
+
+// invalidSchedule records the error of an invalid schedule. There is no
+// point in a requeue: the schedule is reconciled again once it is fixed.
+func (r *TestRunScheduleReconciler) invalidSchedule(schedule *v1alpha1.TestRunSchedule, reason string, err error) ctrl.Result {
+	schedule.Status.LastError = err.Error()
+	r.Recorder.Eventf(schedule, nil, corev1.EventTypeWarning, reason, "Schedule", "%v", err)
+	return ctrl.Result{}
+}
+
+// dueTime returns the latest scheduled time which has not been handled yet,
+// or zero time if there is none, and the next scheduled time after now.
+// Only the latest of several missed times is returned, e.g. after a downtime
+// of the operator, so that missed TestRuns do not pile up.
+func dueTime(schedule *v1alpha1.TestRunSchedule, sched cron.Schedule, now time.Time) (scheduled, next time.Time) {
+	earliest := schedule.CreationTimestamp.Time
+	if schedule.Status.LastScheduleTime != nil {
+		earliest = schedule.Status.LastScheduleTime.Time
+	}
+	for t := sched.Next(earliest.In(now.Location())); !t.IsZero(); t = sched.Next(t) {
+		if t.After(now) {
+			return scheduled, t
+		}
+		scheduled = t
+	}
+	return scheduled, time.Time{}
+}
+
+// newScheduledTestRun builds the TestRun from the template of the schedule.
+func newScheduledTestRun(schedule *v1alpha1.TestRunSchedule, scheduled metav1.Time, scheme *runtime.Scheme) (*v1alpha1.TestRun, error) {
+	return newOwnedTestRun(schedule, schedule.TestRunName(scheduled), &schedule.Spec.TestRunTemplate,
+		map[string]string{v1alpha1.ScheduleLabel: schedule.Name}, scheme)
+}
+
+func sortByCreation(testRuns []*v1alpha1.TestRun) {
+	sort.SliceStable(testRuns, func(i, j int) bool {
+		if testRuns[i].CreationTimestamp.Equal(&testRuns[j].CreationTimestamp) {
+			return testRuns[i].Name < testRuns[j].Name
+		}
+		return testRuns[i].CreationTimestamp.Before(&testRuns[j].CreationTimestamp)
+	})
+}
+
+func (r *TestRunScheduleReconciler) now() time.Time {
+	if r.Now != nil {
+		return r.Now()
+	}
+	return time.Now()
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *TestRunScheduleReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&v1alpha1.TestRunSchedule{}).
+		Owns(&v1alpha1.TestRun{}).
+		Complete(r)
+}
@@ -0,0 +1,246 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/grafana/k6-operator/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/events"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+var scheduleCreated = time.Date(2024, 3, 1, 0, 30, 0, 0, time.UTC)
+
+func testSchedule(policy v1alpha1.ConcurrencyPolicy) *v1alpha1.TestRunSchedule {
+	return &v1alpha1.TestRunSchedule{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "nightly",
+			Namespace:         "default",
+			UID:               "schedule-uid",
+			CreationTimestamp: metav1.NewTime(scheduleCreated),
+		},
+		Spec: v1alpha1.TestRunScheduleSpec{
+			Schedule:          "0 2 * * *",
+			ConcurrencyPolicy: policy,
+			TestRunTemplate: v1alpha1.TestRunObjectTemplate{
+				Metadata: v1alpha1.PodMetadata{Labels: map[string]string{"team": "checkout"}},
+				Spec: v1alpha1.TestRunSpec{
+					Parallelism: 2,
+					Script:      v1alpha1.K6Script{ConfigMap: v1alpha1.K6Configmap{Name: "test", File: "test.js"}},
+				},
+			},
+			SuccessfulRunsHistoryLimit: ptr.To[int32](1),
+			FailedRunsHistoryLimit:     ptr.To[int32](1),
+		},
+	}
+}
+
+// scheduledTestRun is a TestRun created by the schedule at the given time,
+// in the given stage.
+func scheduledTestRun(t *testing.T, r *TestRunScheduleReconciler, schedule *v1alpha1.TestRunSchedule, at time.Time, stage v1alpha1.Stage, result v1alpha1.TestRunResult) *v1alpha1.TestRun {
+	testRun, err := newScheduledTestRun(schedule, metav1.NewTime(at), r.Scheme)
+	require.NoError(t, err)
+	testRun.CreationTimestamp = metav1.NewTime(at)
+	testRun.Status.Stage = stage
+	testRun.Status.Result = result
+	return testRun
+}
+
+func newTestScheduleReconciler(t *testing.T, now time.Time, objs ...client.Object) *TestRunScheduleReconciler {
+	testRunReconciler := newTestReconciler(t)
+
+	return &TestRunScheduleReconciler{
+		Client: fake.NewClientBuilder().
+			WithScheme(testRunReconciler.Scheme).
+			WithObjects(objs...).
+			WithStatusSubresource(&v1alpha1.TestRunSchedule{}).
+			Build(),
+		Log:      logr.Discard(),
+		Scheme:   testRunReconciler.Scheme,
+		Recorder: events.NewFakeRecorder(10),
+		Now:      func() time.Time { return now },
+	}
+}
+
+func reconcileSchedule(t *testing.T, r *TestRunScheduleReconciler) (*v1alpha1.TestRunSchedule, []v1alpha1.TestRun, ctrl.Result) {
+	t.Helper()
+	key := types.NamespacedName{Namespace: "default", Name: "nightly"}
+	result, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: key})
+	require.NoError(t, err)
+
+	schedule := &v1alpha1.TestRunSchedule{}
+	require.NoError(t, r.Get(context.Background(), key, schedule))
+	list := &v1alpha1.TestRunList{}
+	require.NoError(t, r.List(context.Background(), list))
+	return schedule, list.Items, result
+}
+
+func testRunNames(testRuns []v1alpha1.TestRun) []string {
+	names := []string{}
+	for _, testRun := range testRuns {
+		names = append(names, testRun.Name)
+	}
+	return names
+}
+
+func Test_TestRunSchedule_NotDue(t *testing.T) {
+	now := scheduleCreated.Add(time.Hour)
+	r := newTestScheduleReconciler(t, now, testSchedule(v1alpha1.AllowConcurrent))
+
+	schedule, testRuns, result := reconcileSchedule(t, r)
+
+	assert.Empty(t, testRuns)
+	assert.Nil(t, schedule.Status.LastScheduleTime)
+	assert.Equal(t, 30*time.Minute, result.RequeueAfter)
+}
+
+func Test_TestRunSchedule_CreatesTestRun(t *testing.T) {
+	due := time.Date(2024, 3, 1, 2, 0, 0, 0, time.UTC)
+	r := newTestScheduleReconciler(t, due.Add(10*time.Second), testSchedule(v1alpha1.AllowConcurrent))
+
+	schedule, testRuns, result := reconcileSchedule(t, r)
+
+	require.Len(t, testRuns, 1)
+	testRun := testRuns[0]
+	assert.Equal(t, schedule.TestRunName(metav1.NewTime(due)), testRun.Name)
+	assert.Equal(t, "nightly", testRun.Labels[v1alpha1.ScheduleLabel])
+	assert.Equal(t, "checkout", testRun.Labels["team"])
+	assert.Equal(t, int32(2), testRun.Spec.Parallelism)
+	assert.True(t, metav1.IsControlledBy(&testRun, schedule))
+
+	require.NotNil(t, schedule.Status.LastScheduleTime)
+	assert.True(t, due.Equal(schedule.Status.LastScheduleTime.Time))
+	assert.Equal(t, []string{testRun.Name}, schedule.Status.Active)
+	assert.Equal(t, 24*time.Hour-10*time.Second, result.RequeueAfter)
+
+	// A repeated reconcile does not create another TestRun.
+	_, testRuns, _ = reconcileSchedule(t, r)
+	assert.Len(t, testRuns, 1)
+}
+
+func Test_TestRunSchedule_TimeZone(t *testing.T) {
+	schedule := testSchedule(v1alpha1.AllowConcurrent)
+	schedule.Spec.TimeZone = "Europe/Berlin"
+	// 2:00 in Berlin is 1:00 UTC in winter.
+	r := newTestScheduleReconciler(t, time.Date(2024, 3, 1, 1, 0, 30, 0, time.UTC), schedule)
+
+	_, testRuns, _ := reconcileSchedule(t, r)
+	assert.Len(t, testRuns, 1)
+}
+
+func Test_TestRunSchedule_InvalidSchedule(t *testing.T) {
+	schedule := testSchedule(v1alpha1.AllowConcurrent)
+	schedule.Spec.Schedule = "0 25 * * *"
+	r := newTestScheduleReconciler(t, scheduleCreated.Add(48*time.Hour), schedule)
+
+	schedule, testRuns, result := reconcileSchedule(t, r)
+
+	assert.Empty(t, testRuns)
+	assert.NotEmpty(t, schedule.Status.LastError)
+	assert.Equal(t, ctrl.Result{}, result)
+}
+
+func Test_TestRunSchedule_Suspended(t *testing.T) {
+	schedule := testSchedule(v1alpha1.AllowConcurrent)
+	schedule.Spec.Suspend = true
+	r := newTestScheduleReconciler(t, scheduleCreated.Add(48*time.Hour), schedule)
+
+	_, testRuns, _ := reconcileSchedule(t, r)
+	assert.Empty(t, testRuns)
+}
+
+func Test_TestRunSchedule_StartingDeadline(t *testing.T) {
+	schedule := testSchedule(v1alpha1.AllowConcurrent)
+	schedule.Spec.StartingDeadlineSeconds = ptr.To[int64](60)
+	due := time.Date(2024, 3, 1, 2, 0, 0, 0, time.UTC)
+	r := newTestScheduleReconciler(t, due.Add(5*time.Minute), schedule)
+
+	schedule, testRuns, _ := reconcileSchedule(t, r)
+
+	assert.Empty(t, testRuns)
+	require.NotNil(t, schedule.Status.LastScheduleTime)
+	assert.True(t, due.Equal(schedule.Status.LastScheduleTime.Time))
+}
+
+func Test_TestRunSchedule_ConcurrencyPolicy(t *testing.T) {
+	previous := time.Date(2024, 3, 1, 2, 0, 0, 0, time.UTC)
+	due := previous.Add(24 * time.Hour)
+
+	tests := []struct {
+		policy   v1alpha1.ConcurrencyPolicy
+		expected []time.Time
+	}{
+		{v1alpha1.AllowConcurrent, []time.Time{previous, due}},
+		{v1alpha1.ForbidConcurrent, []time.Time{previous}},
+		{v1alpha1.ReplaceConcurrent, []time.Time{due}},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.policy), func(t *testing.T) {
+			schedule := testSchedule(tt.policy)
+			schedule.Status.LastScheduleTime = &metav1.Time{Time: previous}
+			r := newTestScheduleReconciler(t, due.Add(time.Second))
+			running := scheduledTestRun(t, r, schedule, previous, "started", "")
+			require.NoError(t, r.Create(context.Background(), schedule))
+			require.NoError(t, r.Status().Update(context.Background(), schedule))
+			require.NoError(t, r.Create(context.Background(), running))
+
+			schedule, testRuns, _ := reconcileSchedule(t, r)
+
+			expected := []string{}
+			for _, at := range tt.expected {
+				expected = append(expected, schedule.TestRunName(metav1.NewTime(at)))
+			}
+			assert.ElementsMatch(t, expected, testRunNames(testRuns))
+			assert.True(t, due.Equal(schedule.Status.LastScheduleTime.Time))
+		})
+	}
+}
+
+func Test_TestRunSchedule_History(t *testing.T) {
+	schedule := testSchedule(v1alpha1.AllowConcurrent)
+	day := func(d int) time.Time { return time.Date(2024, 3, d, 2, 0, 0, 0, time.UTC) }
+	schedule.Status.LastScheduleTime = &metav1.Time{Time: day(5)}
+
+	r := newTestScheduleReconciler(t, day(5).Add(time.Hour))
+	objs := []*v1alpha1.TestRun{
+		scheduledTestRun(t, r, schedule, day(1), "finished", v1alpha1.ResultPassed),
+		scheduledTestRun(t, r, schedule, day(2), "error", ""),
+		scheduledTestRun(t, r, schedule, day(3), "finished", v1alpha1.ResultPassed),
+		scheduledTestRun(t, r, schedule, day(4), "finished", v1alpha1.ResultThresholdsFailed),
+		scheduledTestRun(t, r, schedule, day(5), "started", ""),
+	}
+	objs[3].Status.Conditions = []metav1.Condition{{Type: v1alpha1.TestRunFailed, Status: metav1.ConditionTrue}}
+	objs[3].Status.StageTransitionTime = &metav1.Time{Time: day(4).Add(30 * time.Minute)}
+	require.NoError(t, r.Create(context.Background(), schedule))
+	require.NoError(t, r.Status().Update(context.Background(), schedule))
+	for _, obj := range objs {
+		require.NoError(t, r.Create(context.Background(), obj))
+	}
+
+	// A TestRun with the label but not owned by the schedule is left alone.
+	foreign := scheduledTestRun(t, r, schedule, day(1), "finished", v1alpha1.ResultPassed)
+	foreign.Name = "foreign"
+	foreign.OwnerReferences = nil
+	require.NoError(t, controllerutil.SetOwnerReference(schedule, foreign, r.Scheme))
+	require.NoError(t, r.Create(context.Background(), foreign))
+
+	schedule, testRuns, _ := reconcileSchedule(t, r)
+
+	assert.ElementsMatch(t, []string{"foreign", objs[2].Name, objs[3].Name, objs[4].Name}, testRunNames(testRuns))
+	assert.Equal(t, []string{objs[4].Name}, schedule.Status.Active)
+	assert.Equal(t, objs[3].Name, schedule.Status.LastTestRun)
+	assert.Equal(t, v1alpha1.ResultThresholdsFailed, schedule.Status.LastResult)
+	require.NotNil(t, schedule.Status.LastCompletionTime)
+	assert.True(t, day(4).Add(30*time.Minute).Equal(schedule.Status.LastCompletionTime.Time))
+}
@@ -0,0 +1,340 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/events"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+
+	"github.com/grafana/k6-operator/api/v1alpha1"
+)
+
+// TestSuiteReconciler reconciles a TestSuite object
+type TestSuiteReconciler struct {
+	client.Client
+	Log      logr.Logger
+	Scheme   *runtime.Scheme
+	Recorder events.EventRecorder
+}
+
+//+kubebuilder:rbac:groups=k6.io,resources=testsuites,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=k6.io,resources=testsuites/status,verbs=get;update;patch
+
+// Reconcile tracks the TestRuns of the started steps of a TestSuite and
+// starts the steps whose dependencies have finished, until all steps are done.
+func (r *TestSuiteReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.Log.WithValues("namespace", req.Namespace, "name", req.Name, "reconcileID", controller.ReconcileIDFromContext(ctx))
+
+	suite := &v1alpha1.TestSuite{}
+	if err := r.Get(ctx, req.NamespacedName, suite); err != nil {
+		if k8sErrors.IsNotFound(err) {
+			log.Info("Request deleted. Nothing to reconcile.")
+			return ctrl.Result{}, nil
+		}
+		log.Error(err, "Could not fetch request")
+		return ctrl.Result{Requeue: true}, err
+	}
+	if !suite.DeletionTimestamp.IsZero() || suite.Status.Phase.IsFinished() {
+		return ctrl.Result{}, nil
+	}
+
+	cleanObj := suite.DeepCopy()
+
+	if err := suite.Spec.Validate(); err != nil {
+		log.Info(fmt.Sprintf("Invalid TestSuite: %v", err))
+		r.Recorder.Eventf(suite, nil, corev1.EventTypeWarning, "InvalidSuite", "Validating", "%v", err)
+		suite.Status.Phase = v1alpha1.SuiteFailed
+		suite.Status.LastError = err.Error()
+		now := metav1.Now()
+		suite.Status.CompletionTime = &now
+		return ctrl.Result{}, r.patchStatus(ctx, log, suite, cleanObj)
+	}
+
+	if suite.Status.StartTime == nil {
+		now := metav1.Now()
+		suite.Status.StartTime = &now
+		suite.Status.Phase = v1alpha1.SuiteRunning
+		r.Recorder.Eventf(suite, nil, corev1.EventTypeNormal, "SuiteStarted", "Starting", "test suite has started")
+	}
+	initStepStatuses(suite)
+
+	if err := r.syncSteps(ctx, log, suite); err != nil {
+		return ctrl.Result{}, err
+	}
+	if err := r.startSteps(ctx, log, suite); err != nil {
+		// The steps started so far must be recorded in the status.
+		_ = r.patchStatus(ctx, log, suite, cleanObj)
+		return ctrl.Result{}, err
+	}
+	if isFailing(suite) {
+		if err := r.stopSteps(ctx, log, suite); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	finishSuite(suite, r.Recorder)
+
+	return ctrl.Result{}, r.patchStatus(ctx, log, suite, cleanObj)
+}
+
+// initStepStatuses adds the status of the steps which do not have one yet.
+func initStepStatuses(suite *v1alpha1.TestSuite) {
+	for _, step := range suite.Spec.Steps {
+		if suite.StepStatus(step.Name) == nil {
+			suite.Status.Steps = append(suite.Status.Steps, v1alpha1.TestSuiteStepStatus{
+				Name:  step.Name,
+				Phase: v1alpha1.SuitePending,
+			})
+		}
+	}
+}
+
+// syncSteps updates the running steps with the state of their TestRuns.
+func (r *TestSuiteReconciler) syncSteps(ctx context.Context, log logr.Logger, suite *v1alpha1.TestSuite) error {
+	for i := range suite.Status.Steps {
+		status := &suite.Status.Steps[i]
+		if status.Phase != v1alpha1.SuiteRunning {
+			continue
+		}
+
+		testRun := &v1alpha1.TestRun{}
+		if err := r.Get(ctx, types.NamespacedName{Namespace: suite.Namespace, Name: status.TestRun}, testRun); err != nil {
+			if !k8sErrors.IsNotFound(err) {
+				log.Error(err, "Could not get TestRun of the step")
+				return err
+			}
+			finishStep(status, v1alpha1.SuiteFailed, "", fmt.Sprintf("TestRun %s was deleted", status.TestRun))
+		} else if isTestRunFinished(testRun) {
+			if testRun.IsFailed() {
+				finishStep(status, v1alpha1.SuiteFailed, testRun.GetStatus().Result, testRun.GetStatus().LastError)
+			} else {
+				finishStep(status, v1alpha1.SuiteSucceeded, testRun.GetStatus().Result, "")
+			}
+		} else {
+			continue
+		}
+
+		log.Info(fmt.Sprintf("Step %s has finished: %s", status.Name, status.Phase))
+		eventType, reason := corev1.EventTypeNormal, "StepSucceeded"
+		if status.Phase == v1alpha1.SuiteFailed {
+			eventType, reason = corev1.EventTypeWarning, "StepFailed"
+		}
+		r.Recorder.Eventf(suite, nil, eventType, reason, "RunStep", "step %s has finished: %s", status.Name, status.Phase)
+	}
+	return nil
+}
+
+// startSteps starts the pending steps whose dependencies have finished and
+// skips the ones which should not run. Skipping a step may finish the
+// dependencies of other steps, so steps are checked until nothing changes.
+func (r *TestSuiteReconciler) startSteps(ctx context.Context, log logr.Logger, suite *v1alpha1.TestSuite) error {
+	for changed := true; changed; {
+		changed = false
+
+		for i := range suite.Spec.Steps {
+			step := &suite.Spec.Steps[i]
+			status := suite.StepStatus(step.Name)
+			if status.Phase != v1alpha1.SuitePending {
+				continue
+			}
+
+			if isFailing(suite) && step.RunCondition != v1alpha1.RunAlways {
+				finishStep(status, v1alpha1.SuiteSkipped, "", "a step has failed and the suite fails fast")
+				changed = true
+				continue
+			}
+
+			run, ready, message := checkRunCondition(suite, step)
+			if !ready {
+				continue
+			}
+			changed = true
+			if !run {
+				log.Info(fmt.Sprintf("Skipping step %s: %s", step.Name, message))
+				finishStep(status, v1alpha1.SuiteSkipped, "", message)
+				continue
+			}
+
+			if err := r.createStepTestRun(ctx, log, suite, step); err != nil {
+				return err
+			}
+			now := metav1.Now()
+			status.Phase = v1alpha1.SuiteRunning
+			status.TestRun = suite.StepTestRunName(step.Name)
+			status.StartTime = &now
+		}
+	}
+	return nil
+}
+
+// checkRunCondition checks if the dependencies of the step have finished
+// and, if so, whether the step should run according to its run condition.
+// The message explains why the step should not run.
+func checkRunCondition(suite *v1alpha1.TestSuite, step *v1alpha1.TestSuiteStep) (run, ready bool, message string) {
+	var succeeded, failed []string
+	for _, dep := range step.DependsOn {
+		switch suite.StepStatus(dep).Phase {
+		case v1alpha1.SuiteSucceeded:
+			succeeded = append(succeeded, dep)
+		case v1alpha1.SuiteFailed:
+			failed = append(failed, dep)
+		case v1alpha1.SuiteSkipped:
+		default:
+			return false, false, ""
+		}
+	}
+
+	switch step.RunCondition {
+	case v1alpha1.RunAlways:
+		return true, true, ""
+	case v1alpha1.RunOnFailure:
+		if len(failed) == 0 {
+			return false, true, "no dependency has failed"
+		}
+		return true, true, ""
+	default:
+		if len(succeeded) < len(step.DependsOn) {
+			return false, true, "not all dependencies have succeeded"
+		}
+		return true, true, ""
+	}
+}
+
+func (r *TestSuiteReconciler) createStepTestRun(ctx context.Context, log logr.Logger, suite *v1alpha1.TestSuite, step *v1alpha1.TestSuiteStep) error {
+	testRun, err := newOwnedTestRun(suite, suite.StepTestRunName(step.Name), &step.TestRun,
+		map[string]string{v1alpha1.SuiteLabel: suite.Name, v1alpha1.SuiteStepLabel: step.Name}, r.Scheme)
+	if err != nil {
+		return err
+	}
+
+	if err := r.Create(ctx, testRun); err != nil && !k8sErrors.IsAlreadyExists(err) {
+		log.Error(err, "Could not create TestRun of the step")
+		r.Recorder.Eventf(suite, nil, corev1.EventTypeWarning, "TestRunCreationFailed", "RunStep",
+			"could not create TestRun %s of step %s: %v", testRun.Name, step.Name, err)
+		return err
+	}
+
+	log.Info(fmt.Sprintf("Started step %s with TestRun %s", step.Name, testRun.Name))
+	r.Recorder.Eventf(suite, testRun, corev1.EventTypeNormal, "StepStarted", "RunStep",
+		"step %s has started with TestRun %s", step.Name, testRun.Name)
+	return nil
+}
+
+// stopSteps requests a graceful stop of the running TestRuns of a suite
+// which fails fast. Steps which run Always are not stopped.
+func (r *TestSuiteReconciler) stopSteps(ctx context.Context, log logr.Logger, suite *v1alpha1.TestSuite) error {
+	for _, step := range suite.Spec.Steps {
+		status := suite.StepStatus(step.Name)
+		if status.Phase != v1alpha1.SuiteRunning || step.RunCondition == v1alpha1.RunAlways {
+			continue
+		}
+
+		testRun := &v1alpha1.TestRun{}
+		if err := r.Get(ctx, types.NamespacedName{Namespace: suite.Namespace, Name: status.TestRun}, testRun); err != nil {
+			if k8sErrors.IsNotFound(err) {
+				continue
+			}
+			return err
+		}
+		if testRun.IsStopRequested() {
+			continue
+		}
+
+		log.Info(fmt.Sprintf("Stopping TestRun %s of step %s", testRun.Name, step.Name))
+		patch := client.MergeFrom(testRun.DeepCopy())
+		if testRun.Annotations == nil {
+			testRun.Annotations = map[string]string{}
+		}
+		testRun.Annotations[v1alpha1.StopAnnotation] = "true"
+		if err := r.Patch(ctx, testRun, patch); err != nil {
+			log.Error(err, "Could not stop TestRun of the step")
+			return err
+		}
+	}
+	return nil
+}
+
+// isFailing checks if the suite fails fast and one of its steps has failed.
+func isFailing(suite *v1alpha1.TestSuite) bool {
+	if !suite.Spec.FailFast {
+		return false
+	}
+	for _, status := range suite.Status.Steps {
+		if status.Phase == v1alpha1.SuiteFailed {
+			return true
+		}
+	}
+	return false
+}
+
+func finishStep(status *v1alpha1.TestSuiteStepStatus, phase v1alpha1.TestSuitePhase, result v1alpha1.TestRunResult, message string) {
+	now := metav1.Now()
+	status.Phase = phase
+	status.Result = result
+	status.Message = message
+	status.CompletionTime = &now
+}
+
+// finishSuite sets the phase of the suite once all of its steps have finished.
+func finishSuite(suite *v1alpha1.TestSuite, recorder events.EventRecorder) {
+	phase := v1alpha1.SuiteSucceeded
+	for _, status := range suite.Status.Steps {
+		if !status.Phase.IsFinished() {
+			return
+		}
+		if status.Phase == v1alpha1.SuiteFailed {
+			phase = v1alpha1.SuiteFailed
+		}
+	}
+
+	now := metav1.Now()
+	suite.Status.Phase = phase
+	suite.Status.CompletionTime = &now
+
+	if phase == v1alpha1.SuiteFailed {
+		recorder.Eventf(suite, nil, corev1.EventTypeWarning, "SuiteFailed", "Finishing", "test suite has failed")
+	} else {
+		recorder.Eventf(suite, nil, corev1.EventTypeNormal, "SuiteSucceeded", "Finishing", "test suite has succeeded")
+	}
+}
+
+func (r *TestSuiteReconciler) patchStatus(ctx context.Context, log logr.Logger, suite, cleanObj *v1alpha1.TestSuite) error {
+	if err := r.Status().Patch(ctx, suite, client.MergeFrom(cleanObj)); err != nil {
+		log.Error(err, "Could not update status of TestSuite")
+		return err
+	}
+	return nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *TestSuiteReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&v1alpha1.TestSuite{}).
+		Owns(&v1alpha1.TestRun{}).
+		Complete(r)
+}
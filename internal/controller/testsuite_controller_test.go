@@ -0,0 +1,210 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/grafana/k6-operator/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/events"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func suiteStep(name string, condition v1alpha1.RunCondition, dependsOn ...string) v1alpha1.TestSuiteStep {
+	return v1alpha1.TestSuiteStep{
+		Name:         name,
+		DependsOn:    dependsOn,
+		RunCondition: condition,
+		TestRun: v1alpha1.TestRunObjectTemplate{
+			Spec: v1alpha1.TestRunSpec{
+				Parallelism: 1,
+				Script:      v1alpha1.K6Script{ConfigMap: v1alpha1.K6Configmap{Name: "test", File: name + ".js"}},
+			},
+		},
+	}
+}
+
+func newTestSuiteReconciler(t *testing.T, suite *v1alpha1.TestSuite) *TestSuiteReconciler {
+	scheme := newTestReconciler(t).Scheme
+
+	return &TestSuiteReconciler{
+		Client: fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithObjects(suite).
+			WithStatusSubresource(&v1alpha1.TestSuite{}, &v1alpha1.TestRun{}).
+			Build(),
+		Log:      logr.Discard(),
+		Scheme:   scheme,
+		Recorder: events.NewFakeRecorder(20),
+	}
+}
+
+func reconcileSuite(t *testing.T, r *TestSuiteReconciler) *v1alpha1.TestSuite {
+	t.Helper()
+	key := types.NamespacedName{Namespace: "default", Name: "release"}
+	_, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: key})
+	require.NoError(t, err)
+
+	suite := &v1alpha1.TestSuite{}
+	require.NoError(t, r.Get(context.Background(), key, suite))
+	return suite
+}
+
+// finishStepTestRun moves the TestRun of the step to its final stage.
+func finishStepTestRun(t *testing.T, r *TestSuiteReconciler, step string, failed bool) {
+	t.Helper()
+	testRun := &v1alpha1.TestRun{}
+	require.NoError(t, r.Get(context.Background(), types.NamespacedName{Namespace: "default", Name: "release-" + step}, testRun))
+
+	testRun.Status.Stage = "finished"
+	testRun.Status.Result = v1alpha1.ResultPassed
+	if failed {
+		testRun.Status.Stage = "error"
+		testRun.Status.Result = v1alpha1.ResultThresholdsFailed
+	}
+	require.NoError(t, r.Status().Update(context.Background(), testRun))
+}
+
+func stepPhases(suite *v1alpha1.TestSuite) map[string]v1alpha1.TestSuitePhase {
+	phases := map[string]v1alpha1.TestSuitePhase{}
+	for _, status := range suite.Status.Steps {
+		phases[status.Name] = status.Phase
+	}
+	return phases
+}
+
+func Test_TestSuite_DAG(t *testing.T) {
+	suite := &v1alpha1.TestSuite{
+		ObjectMeta: metav1.ObjectMeta{Name: "release", Namespace: "default", UID: "suite-uid"},
+		Spec: v1alpha1.TestSuiteSpec{
+			Steps: []v1alpha1.TestSuiteStep{
+				suiteStep("smoke", ""),
+				suiteStep("load-a", v1alpha1.RunOnSuccess, "smoke"),
+				suiteStep("load-b", v1alpha1.RunOnSuccess, "smoke"),
+				suiteStep("spike", v1alpha1.RunOnSuccess, "load-a", "load-b"),
+				suiteStep("rollback", v1alpha1.RunOnFailure, "load-a", "load-b"),
+				suiteStep("report", v1alpha1.RunAlways, "spike"),
+			},
+		},
+	}
+	r := newTestSuiteReconciler(t, suite)
+
+	suite = reconcileSuite(t, r)
+	assert.Equal(t, v1alpha1.SuiteRunning, suite.Status.Phase)
+	assert.Equal(t, v1alpha1.SuiteRunning, stepPhases(suite)["smoke"])
+	assert.Equal(t, v1alpha1.SuitePending, stepPhases(suite)["load-a"])
+
+	testRun := &v1alpha1.TestRun{}
+	require.NoError(t, r.Get(context.Background(), types.NamespacedName{Namespace: "default", Name: "release-smoke"}, testRun))
+	assert.Equal(t, "release", testRun.Labels[v1alpha1.SuiteLabel])
+	assert.Equal(t, "smoke", testRun.Labels[v1alpha1.SuiteStepLabel])
+	assert.True(t, metav1.IsControlledBy(testRun, suite))
+
+	// The load tests start in parallel once the smoke test has passed.
+	finishStepTestRun(t, r, "smoke", false)
+	suite = reconcileSuite(t, r)
+	assert.Equal(t, v1alpha1.SuiteSucceeded, stepPhases(suite)["smoke"])
+	assert.Equal(t, v1alpha1.SuiteRunning, stepPhases(suite)["load-a"])
+	assert.Equal(t, v1alpha1.SuiteRunning, stepPhases(suite)["load-b"])
+
+	// A failed load test skips the spike test and starts the rollback,
+	// while the report runs even after the skipped spike test.
+	finishStepTestRun(t, r, "load-a", false)
+	finishStepTestRun(t, r, "load-b", true)
+	suite = reconcileSuite(t, r)
+	assert.Equal(t, map[string]v1alpha1.TestSuitePhase{
+		"smoke":    v1alpha1.SuiteSucceeded,
+		"load-a":   v1alpha1.SuiteSucceeded,
+		"load-b":   v1alpha1.SuiteFailed,
+		"spike":    v1alpha1.SuiteSkipped,
+		"rollback": v1alpha1.SuiteRunning,
+		"report":   v1alpha1.SuiteRunning,
+	}, stepPhases(suite))
+	assert.Equal(t, v1alpha1.ResultThresholdsFailed, suite.StepStatus("load-b").Result)
+	assert.Equal(t, v1alpha1.SuiteRunning, suite.Status.Phase)
+
+	finishStepTestRun(t, r, "rollback", false)
+	finishStepTestRun(t, r, "report", false)
+	suite = reconcileSuite(t, r)
+	assert.Equal(t, v1alpha1.SuiteFailed, suite.Status.Phase)
+	assert.NotNil(t, suite.Status.CompletionTime)
+}
+
+func Test_TestSuite_Succeeded(t *testing.T) {
+	suite := &v1alpha1.TestSuite{
+		ObjectMeta: metav1.ObjectMeta{Name: "release", Namespace: "default", UID: "suite-uid"},
+		Spec: v1alpha1.TestSuiteSpec{
+			Steps: []v1alpha1.TestSuiteStep{
+				suiteStep("smoke", v1alpha1.RunOnSuccess),
+				suiteStep("rollback", v1alpha1.RunOnFailure, "smoke"),
+			},
+		},
+	}
+	r := newTestSuiteReconciler(t, suite)
+
+	reconcileSuite(t, r)
+	finishStepTestRun(t, r, "smoke", false)
+	suite = reconcileSuite(t, r)
+
+	assert.Equal(t, v1alpha1.SuiteSkipped, stepPhases(suite)["rollback"])
+	assert.Equal(t, v1alpha1.SuiteSucceeded, suite.Status.Phase)
+}
+
+func Test_TestSuite_FailFast(t *testing.T) {
+	suite := &v1alpha1.TestSuite{
+		ObjectMeta: metav1.ObjectMeta{Name: "release", Namespace: "default", UID: "suite-uid"},
+		Spec: v1alpha1.TestSuiteSpec{
+			FailFast: true,
+			Steps: []v1alpha1.TestSuiteStep{
+				suiteStep("load-a", v1alpha1.RunOnSuccess),
+				suiteStep("load-b", v1alpha1.RunOnSuccess),
+				suiteStep("cleanup", v1alpha1.RunAlways),
+				suiteStep("spike", v1alpha1.RunOnSuccess, "load-a"),
+				suiteStep("rollback", v1alpha1.RunOnFailure, "load-a"),
+			},
+		},
+	}
+	r := newTestSuiteReconciler(t, suite)
+
+	reconcileSuite(t, r)
+	finishStepTestRun(t, r, "load-b", true)
+	suite = reconcileSuite(t, r)
+
+	assert.Equal(t, v1alpha1.SuiteFailed, stepPhases(suite)["load-b"])
+	assert.Equal(t, v1alpha1.SuiteSkipped, stepPhases(suite)["spike"])
+	assert.Equal(t, v1alpha1.SuiteSkipped, stepPhases(suite)["rollback"])
+
+	// The running TestRun is stopped, unless its step runs always.
+	for step, stopped := range map[string]bool{"load-a": true, "cleanup": false} {
+		testRun := &v1alpha1.TestRun{}
+		require.NoError(t, r.Get(context.Background(), types.NamespacedName{Namespace: "default", Name: "release-" + step}, testRun))
+		assert.Equal(t, stopped, testRun.IsStopRequested(), step)
+	}
+}
+
+func Test_TestSuite_Invalid(t *testing.T) {
+	suite := &v1alpha1.TestSuite{
+		ObjectMeta: metav1.ObjectMeta{Name: "release", Namespace: "default", UID: "suite-uid"},
+		Spec: v1alpha1.TestSuiteSpec{
+			Steps: []v1alpha1.TestSuiteStep{
+				suiteStep("a", v1alpha1.RunOnSuccess, "b"),
+				suiteStep("b", v1alpha1.RunOnSuccess, "a"),
+			},
+		},
+	}
+	r := newTestSuiteReconciler(t, suite)
+
+	suite = reconcileSuite(t, r)
+
+	assert.Equal(t, v1alpha1.SuiteFailed, suite.Status.Phase)
+	assert.Contains(t, suite.Status.LastError, "dependency cycle")
+
+	testRuns := &v1alpha1.TestRunList{}
+	require.NoError(t, r.List(context.Background(), testRuns))
+	assert.Empty(t, testRuns.Items)
+}
@@ -0,0 +1,92 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/grafana/k6-operator/api/v1alpha1"
+)
+
+// +kubebuilder:webhook:path=/validate-k6-io-v1alpha1-privateloadzone,mutating=false,failurePolicy=fail,sideEffects=None,groups=k6.io,resources=privateloadzones,verbs=create;update,versions=v1alpha1,name=vprivateloadzone-v1alpha1.k6.io,admissionReviewVersions=v1
+
+// PrivateLoadZoneValidator rejects PrivateLoadZones that cannot be
+// registered with Grafana Cloud k6.
+type PrivateLoadZoneValidator struct{}
+
+var _ admission.Validator[*v1alpha1.PrivateLoadZone] = &PrivateLoadZoneValidator{}
+
+// SetupPrivateLoadZoneWebhookWithManager registers the validating webhook for PrivateLoadZone.
+func SetupPrivateLoadZoneWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr, &v1alpha1.PrivateLoadZone{}).
+		WithValidator(&PrivateLoadZoneValidator{}).
+		Complete()
+}
+
+// ValidateCreate implements admission.Validator.
+func (v *PrivateLoadZoneValidator) ValidateCreate(ctx context.Context, plz *v1alpha1.PrivateLoadZone) (admission.Warnings, error) {
+	return nil, validatePrivateLoadZone(plz)
+}
+
+// ValidateUpdate implements admission.Validator.
+// As with TestRun, updates that don't touch the spec are always allowed:
+// PLZ controller relies on them to add and remove its finalizer.
+func (v *PrivateLoadZoneValidator) ValidateUpdate(ctx context.Context, oldPLZ, newPLZ *v1alpha1.PrivateLoadZone) (admission.Warnings, error) {
+	if equality.Semantic.DeepEqual(oldPLZ.Spec, newPLZ.Spec) {
+		return nil, nil
+	}
+	return nil, validatePrivateLoadZone(newPLZ)
+}
+
+// ValidateDelete implements admission.Validator.
+func (v *PrivateLoadZoneValidator) ValidateDelete(ctx context.Context, plz *v1alpha1.PrivateLoadZone) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func validatePrivateLoadZone(plz *v1alpha1.PrivateLoadZone) error {
+	var (
+		allErrs  field.ErrorList
+		specPath = field.NewPath("spec")
+	)
+
+	if len(plz.Spec.Token) == 0 {
+		allErrs = append(allErrs, field.Required(specPath.Child("token"),
+			"name of the Secret with Grafana Cloud k6 token must be provided"))
+	}
+
+	limitsPath := specPath.Child("resources", "limits")
+	if plz.Spec.Resources.Limits.Cpu().IsZero() {
+		allErrs = append(allErrs, field.Required(limitsPath.Child("cpu"),
+			"resources.limits is mandatory to register with Grafana Cloud k6"))
+	}
+	if plz.Spec.Resources.Limits.Memory().IsZero() {
+		allErrs = append(allErrs, field.Required(limitsPath.Child("memory"),
+			"resources.limits is mandatory to register with Grafana Cloud k6"))
+	}
+
+	if len(allErrs) > 0 {
+		return apierrors.NewInvalid(v1alpha1.GroupVersion.WithKind("PrivateLoadZone").GroupKind(), plz.Name, allErrs)
+	}
+
+	return nil
+}
@@ -0,0 +1,42 @@
+package v1alpha1
+
+import (
+	"context"
+	"testing"
+
+	"github.com/grafana/k6-operator/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_PrivateLoadZoneValidator_ValidateCreate(t *testing.T) {
+	plz := &v1alpha1.PrivateLoadZone{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "plz",
+		},
+		Spec: v1alpha1.PrivateLoadZoneSpec{
+			Token: "token",
+			Resources: corev1.ResourceRequirements{
+				Limits: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("1"),
+					corev1.ResourceMemory: resource.MustParse("1Gi"),
+				},
+			},
+		},
+	}
+
+	_, err := (&PrivateLoadZoneValidator{}).ValidateCreate(context.Background(), plz)
+	require.NoError(t, err)
+
+	plz.Spec.Token = ""
+	delete(plz.Spec.Resources.Limits, corev1.ResourceMemory)
+
+	_, err = (&PrivateLoadZoneValidator{}).ValidateCreate(context.Background(), plz)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "spec.token")
+	assert.Contains(t, err.Error(), "spec.resources.limits.memory")
+	assert.NotContains(t, err.Error(), "spec.resources.limits.cpu")
+}
@@ -0,0 +1,158 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/grafana/k6-operator/api/v1alpha1"
+)
+
+// +kubebuilder:webhook:path=/validate-k6-io-v1alpha1-testrun,mutating=false,failurePolicy=fail,sideEffects=None,groups=k6.io,resources=testruns,verbs=create;update,versions=v1alpha1,name=vtestrun-v1alpha1.k6.io,admissionReviewVersions=v1
+
+// TestRunValidator rejects invalid TestRuns at admission time, with the same
+// checks that the TestRun controller runs at the beginning of reconciliation.
+type TestRunValidator struct{}
+
+var _ admission.Validator[*v1alpha1.TestRun] = &TestRunValidator{}
+
+// SetupTestRunWebhookWithManager registers the validating webhook for TestRun.
+func SetupTestRunWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr, &v1alpha1.TestRun{}).
+		WithValidator(&TestRunValidator{}).
+		Complete()
+}
+
+// ValidateCreate implements admission.Validator.
+func (v *TestRunValidator) ValidateCreate(ctx context.Context, k6 *v1alpha1.TestRun) (admission.Warnings, error) {
+	return validateTestRun(k6)
+}
+
+// ValidateUpdate implements admission.Validator.
+// Updates that don't touch the spec, e.g. adding a finalizer or a label,
+// are always allowed, so that an existing TestRun can never get stuck.
+func (v *TestRunValidator) ValidateUpdate(ctx context.Context, oldK6, newK6 *v1alpha1.TestRun) (admission.Warnings, error) {
+	if equality.Semantic.DeepEqual(oldK6.Spec, newK6.Spec) {
+		return nil, nil
+	}
+	return validateTestRun(newK6)
+}
+
+// ValidateDelete implements admission.Validator.
+func (v *TestRunValidator) ValidateDelete(ctx context.Context, k6 *v1alpha1.TestRun) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func validateTestRun(k6 *v1alpha1.TestRun) (admission.Warnings, error) {
+	var (
+		allErrs  field.ErrorList
+		specPath = field.NewPath("spec")
+	)
+
+	// With a template, the parallelism and the script may come from it:
+	// they are validated once the template is resolved.
+	withTemplate := k6.Spec.TemplateRef != nil
+
+	if k6.Spec.Parallelism < 1 && !withTemplate {
+		allErrs = append(allErrs, field.Invalid(specPath.Child("parallelism"), k6.Spec.Parallelism,
+			"parallelism of TestRun cannot be less than 1"))
+	}
+
+	warnings, err := k6.GetSpec().Validate()
+	if err != nil {
+		argsPath := specPath.Child("arguments")
+		if len(k6.Spec.Args) > 0 {
+			argsPath = specPath.Child("args")
+		}
+		allErrs = append(allErrs, field.Invalid(argsPath, k6.Spec.Argv(), err.Error()))
+	}
+
+	if _, err := k6.GetSpec().ParseScript(); err != nil && (!withTemplate || k6.Spec.Script != (v1alpha1.K6Script{})) {
+		allErrs = append(allErrs, field.Required(specPath.Child("script"), err.Error()))
+	}
+
+	if timeouts := k6.Spec.Timeouts; timeouts != nil {
+		timeoutsPath := specPath.Child("timeouts")
+		for _, t := range []struct {
+			name string
+			d    *metav1.Duration
+		}{
+			{"initialization", timeouts.Initialization},
+			{"runnersReady", timeouts.RunnersReady},
+			{"start", timeouts.Start},
+			{"run", timeouts.Run},
+		} {
+			if t.d != nil && t.d.Duration < 0 {
+				allErrs = append(allErrs, field.Invalid(timeoutsPath.Child(t.name), t.d.Duration.String(),
+					"timeout cannot be negative"))
+			}
+		}
+	}
+
+	if policy := k6.Spec.CleanupPolicy; policy != nil {
+		allErrs = append(allErrs, validateCleanupPolicy(k6, specPath.Child("cleanupPolicy"))...)
+	}
+
+	if len(allErrs) > 0 {
+		return warnings, apierrors.NewInvalid(v1alpha1.GroupVersion.WithKind("TestRun").GroupKind(), k6.Name, allErrs)
+	}
+
+	return warnings, nil
+}
+
+func validateCleanupPolicy(k6 *v1alpha1.TestRun, policyPath *field.Path) (allErrs field.ErrorList) {
+	policy := k6.Spec.CleanupPolicy
+
+	if len(k6.Spec.Cleanup) > 0 {
+		allErrs = append(allErrs, field.Forbidden(policyPath, "cleanupPolicy cannot be combined with cleanup"))
+	}
+
+	switch policy.Type {
+	case v1alpha1.CleanupDeleteResources, v1alpha1.CleanupDeleteTestRun:
+		if policy.TTL != nil && policy.TTL.Duration < 0 {
+			allErrs = append(allErrs, field.Invalid(policyPath.Child("ttl"), policy.TTL.Duration.String(),
+				"TTL cannot be negative"))
+		}
+
+	case v1alpha1.CleanupKeepLastN:
+		if policy.KeepLast < 1 {
+			allErrs = append(allErrs, field.Invalid(policyPath.Child("keepLast"), policy.KeepLast,
+				"at least one TestRun must be kept"))
+		}
+		if len(policy.GroupLabel) == 0 {
+			allErrs = append(allErrs, field.Required(policyPath.Child("groupLabel"),
+				"groupLabel is required with KeepLastN"))
+		} else if _, ok := k6.Labels[policy.GroupLabel]; !ok {
+			allErrs = append(allErrs, field.Invalid(policyPath.Child("groupLabel"), policy.GroupLabel,
+				"TestRun must have the group label"))
+		}
+
+	default:
+		allErrs = append(allErrs, field.NotSupported(policyPath.Child("type"), policy.Type, []v1alpha1.CleanupPolicyType{
+			v1alpha1.CleanupDeleteResources, v1alpha1.CleanupDeleteTestRun, v1alpha1.CleanupKeepLastN,
+		}))
+	}
+
+	return allErrs
+}
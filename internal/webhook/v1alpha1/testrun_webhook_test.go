@@ -0,0 +1,195 @@
+package v1alpha1
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/grafana/k6-operator/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func validTestRun() *v1alpha1.TestRun {
+	return &v1alpha1.TestRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "test",
+		},
+		Spec: v1alpha1.TestRunSpec{
+			Parallelism: 2,
+			Script: v1alpha1.K6Script{
+				ConfigMap: v1alpha1.K6Configmap{
+					Name: "test",
+					File: "test.js",
+				},
+			},
+		},
+	}
+}
+
+func Test_TestRunValidator_ValidateCreate(t *testing.T) {
+	tests := []struct {
+		name        string
+		modify      func(k6 *v1alpha1.TestRun)
+		expectedErr []string
+		warnings    int
+	}{
+		{
+			name:   "valid TestRun",
+			modify: func(k6 *v1alpha1.TestRun) {},
+		},
+		{
+			name: "parallelism less than 1",
+			modify: func(k6 *v1alpha1.TestRun) {
+				k6.Spec.Parallelism = 0
+			},
+			expectedErr: []string{"spec.parallelism"},
+		},
+		{
+			name: "no script source",
+			modify: func(k6 *v1alpha1.TestRun) {
+				k6.Spec.Script = v1alpha1.K6Script{}
+			},
+			expectedErr: []string{"spec.script"},
+		},
+		{
+			name: "parallelism and script from the template",
+			modify: func(k6 *v1alpha1.TestRun) {
+				k6.Spec.TemplateRef = &v1alpha1.TestRunTemplateReference{Name: "defaults"}
+				k6.Spec.Parallelism = 0
+				k6.Spec.Script = v1alpha1.K6Script{}
+			},
+		},
+		{
+			name: "invalid script with a template",
+			modify: func(k6 *v1alpha1.TestRun) {
+				k6.Spec.TemplateRef = &v1alpha1.TestRunTemplateReference{Name: "defaults"}
+				k6.Spec.Script = v1alpha1.K6Script{Git: &v1alpha1.K6GitScript{URL: "https://example.com/tests.git", Path: "../tests"}}
+			},
+			expectedErr: []string{"spec.script"},
+		},
+		{
+			name: "invalid arguments",
+			modify: func(k6 *v1alpha1.TestRun) {
+				k6.Spec.Arguments = "script.js --out cloud"
+			},
+			expectedErr: []string{"spec.arguments", "script.js"},
+		},
+		{
+			name: "invalid args",
+			modify: func(k6 *v1alpha1.TestRun) {
+				k6.Spec.Args = []string{"--tag", "foo=bar", ""}
+			},
+			expectedErr: []string{"spec.args"},
+		},
+		{
+			name: "all errors are reported together",
+			modify: func(k6 *v1alpha1.TestRun) {
+				k6.Spec.Parallelism = -1
+				k6.Spec.Script = v1alpha1.K6Script{}
+			},
+			expectedErr: []string{"spec.parallelism", "spec.script"},
+		},
+		{
+			name: "negative timeout",
+			modify: func(k6 *v1alpha1.TestRun) {
+				k6.Spec.Timeouts = &v1alpha1.TestRunTimeouts{
+					Initialization: &metav1.Duration{Duration: time.Minute},
+					Run:            &metav1.Duration{Duration: -time.Minute},
+				}
+			},
+			expectedErr: []string{"spec.timeouts.run"},
+		},
+		{
+			name: "valid cleanup policy",
+			modify: func(k6 *v1alpha1.TestRun) {
+				k6.Labels = map[string]string{"suite": "checkout"}
+				k6.Spec.CleanupPolicy = &v1alpha1.CleanupPolicy{
+					Type:       v1alpha1.CleanupKeepLastN,
+					KeepLast:   3,
+					GroupLabel: "suite",
+				}
+			},
+		},
+		{
+			name: "cleanup policy combined with cleanup",
+			modify: func(k6 *v1alpha1.TestRun) {
+				k6.Spec.Cleanup = "post"
+				k6.Spec.CleanupPolicy = &v1alpha1.CleanupPolicy{
+					Type: v1alpha1.CleanupDeleteTestRun,
+					TTL:  &metav1.Duration{Duration: time.Hour},
+				}
+			},
+			expectedErr: []string{"spec.cleanupPolicy"},
+		},
+		{
+			name: "keep last N without group label",
+			modify: func(k6 *v1alpha1.TestRun) {
+				k6.Spec.CleanupPolicy = &v1alpha1.CleanupPolicy{
+					Type:       v1alpha1.CleanupKeepLastN,
+					KeepLast:   3,
+					GroupLabel: "suite",
+				}
+			},
+			expectedErr: []string{"spec.cleanupPolicy.groupLabel"},
+		},
+		{
+			name: "deprecated scuttle is a warning",
+			modify: func(k6 *v1alpha1.TestRun) {
+				k6.Spec.Scuttle.Enabled = "true"
+			},
+			warnings: 1,
+		},
+		{
+			name: "deprecated scuttle is a warning even when TestRun is invalid",
+			modify: func(k6 *v1alpha1.TestRun) {
+				k6.Spec.Scuttle.Enabled = "true"
+				k6.Spec.Parallelism = 0
+			},
+			expectedErr: []string{"spec.parallelism"},
+			warnings:    1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k6 := validTestRun()
+			tt.modify(k6)
+
+			warnings, err := (&TestRunValidator{}).ValidateCreate(context.Background(), k6)
+			assert.Len(t, warnings, tt.warnings)
+
+			if len(tt.expectedErr) == 0 {
+				require.NoError(t, err)
+				return
+			}
+
+			require.Error(t, err)
+			assert.True(t, apierrors.IsInvalid(err))
+			for _, s := range tt.expectedErr {
+				assert.Contains(t, err.Error(), s)
+			}
+		})
+	}
+}
+
+func Test_TestRunValidator_ValidateUpdate(t *testing.T) {
+	oldK6 := validTestRun()
+	oldK6.Spec.Parallelism = 0
+
+	// metadata-only change of an invalid object must be allowed
+	newK6 := oldK6.DeepCopy()
+	newK6.Finalizers = []string{"k6.io/finalizer"}
+
+	_, err := (&TestRunValidator{}).ValidateUpdate(context.Background(), oldK6, newK6)
+	require.NoError(t, err)
+
+	// but a change of the spec must be validated
+	newK6.Spec.Arguments = "--verbose"
+	_, err = (&TestRunValidator{}).ValidateUpdate(context.Background(), oldK6, newK6)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "spec.parallelism")
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/sirupsen/logrus"
 	"go.k6.io/k6/v2/cloudapi"
 	null "gopkg.in/guregu/null.v3"
+	"k8s.io/apimachinery/pkg/types"
 )
 
 type TestRun struct {
@@ -43,7 +44,7 @@ func NewClient(logger logr.Logger, token, host string) *cloudapi.Client {
 	return cloudapi.NewClient(logrusLogger, token, host, "1.2.3", time.Duration(time.Minute))
 }
 
-func CreateTestRun(opts InspectOutput, instances int32, client *cloudapi.Client, log logr.Logger) (*cloudapi.CreateTestRunResponse, error) {
+func CreateTestRun(opts InspectOutput, instances int32, client *cloudapi.Client, testRunName types.NamespacedName, log logr.Logger) (*cloudapi.CreateTestRunResponse, error) {
 	cloudConfig := cloudapi.NewConfig()
 
 	if opts.ProjectID() > 0 {
@@ -66,12 +67,12 @@ func CreateTestRun(opts InspectOutput, instances int32, client *cloudapi.Client,
 		ProcessThresholds: true,
 		Instances:         instances,
 	}
-	return createTestRun(client, &tr)
+	return createTestRun(client, testRunName, &tr)
 }
 
 // We cannot use cloudapi.TestRun struct and cloudapi.Client.CreateTestRun call because they're not aware of
 // process_thresholds argument; so let's use custom struct and function instead
-func createTestRun(client *cloudapi.Client, testRun *TestRun) (*cloudapi.CreateTestRunResponse, error) {
+func createTestRun(client *cloudapi.Client, testRunName types.NamespacedName, testRun *TestRun) (*cloudapi.CreateTestRunResponse, error) {
 	url := client.BaseURL() + "/tests"
 	req, err := client.NewRequest("POST", url, testRun)
 	if err != nil {
@@ -79,7 +80,7 @@ func createTestRun(client *cloudapi.Client, testRun *TestRun) (*cloudapi.CreateT
 	}
 
 	ctrr := cloudapi.CreateTestRunResponse{}
-	err = client.Do(req, &ctrr)
+	err = observe(testRunName, "create_test_run", func() error { return client.Do(req, &ctrr) })
 	if err != nil {
 		return nil, err
 	}
@@ -91,8 +92,19 @@ func createTestRun(client *cloudapi.Client, testRun *TestRun) (*cloudapi.CreateT
 	return &ctrr, nil
 }
 
-func FinishTestRun(c *cloudapi.Client, refID string) error {
-	return c.TestFinished(refID, cloudapi.ThresholdResult(
-		map[string]map[string]bool{},
-	), false, cloudapi.RunStatusFinished)
+func FinishTestRun(c *cloudapi.Client, testRunName types.NamespacedName, refID string) error {
+	return observe(testRunName, "finish_test_run", func() error {
+		return c.TestFinished(refID, cloudapi.ThresholdResult(
+			map[string]map[string]bool{},
+		), false, cloudapi.RunStatusFinished)
+	})
+}
+
+// AbortTestRun finalizes the test run in k6 Cloud as aborted by user.
+func AbortTestRun(c *cloudapi.Client, testRunName types.NamespacedName, refID string) error {
+	return observe(testRunName, "abort_test_run", func() error {
+		return c.TestFinished(refID, cloudapi.ThresholdResult(
+			map[string]map[string]bool{},
+		), false, cloudapi.RunStatusAbortedUser)
+	})
 }
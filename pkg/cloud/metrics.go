@@ -0,0 +1,67 @@
+package cloud
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var (
+	apiRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "k6_operator_cloud_api_requests_total",
+		Help: "Number of requests to k6 Cloud API by TestRun, operation and result.",
+	}, []string{"namespace", "testrun", "operation", "result"})
+
+	apiRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "k6_operator_cloud_api_request_duration_seconds",
+		Help:    "Latency of requests to k6 Cloud API by TestRun and operation.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"namespace", "testrun", "operation"})
+
+	plzPolls = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "k6_operator_plz_polls_total",
+		Help: "Number of polls of k6 Cloud for test runs of a PLZ by result.",
+	}, []string{"plz", "result"})
+
+	plzPolledTestRuns = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "k6_operator_plz_polled_test_runs",
+		Help: "Number of test runs retrieved by the last successful poll of a PLZ.",
+	}, []string{"plz"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(apiRequests, apiRequestDuration, plzPolls, plzPolledTestRuns)
+}
+
+// observe records the latency and the result of a call to k6 Cloud API
+// made for the TestRun. Calls which are not made for a TestRun, e.g. those
+// of a PLZ, are recorded with an empty name.
+func observe(testRunName types.NamespacedName, operation string, call func() error) error {
+	start := time.Now()
+	err := call()
+	apiRequestDuration.WithLabelValues(testRunName.Namespace, testRunName.Name, operation).Observe(time.Since(start).Seconds())
+	apiRequests.WithLabelValues(testRunName.Namespace, testRunName.Name, operation, resultLabel(err)).Inc()
+	return err
+}
+
+func resultLabel(err error) string {
+	if err != nil {
+		return "error"
+	}
+	return "success"
+}
+
+// DeleteTestRunMetrics removes all series of a deleted TestRun.
+func DeleteTestRunMetrics(testRunName types.NamespacedName) {
+	labels := prometheus.Labels{"namespace": testRunName.Namespace, "testrun": testRunName.Name}
+	apiRequests.DeletePartialMatch(labels)
+	apiRequestDuration.DeletePartialMatch(labels)
+}
+
+// DeletePLZMetrics removes all series of a deleted PLZ.
+func DeletePLZMetrics(plzName string) {
+	plzPolls.DeletePartialMatch(prometheus.Labels{"plz": plzName})
+	plzPolledTestRuns.DeleteLabelValues(plzName)
+}
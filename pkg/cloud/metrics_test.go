@@ -0,0 +1,24 @@
+package cloud
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func Test_observe(t *testing.T) {
+	testRunName := types.NamespacedName{Namespace: "default", Name: "metrics"}
+
+	_ = observe(testRunName, "finish_test_run", func() error { return nil })
+	_ = observe(testRunName, "finish_test_run", func() error { return errors.New("unavailable") })
+
+	assert.Equal(t, 1.0, testutil.ToFloat64(apiRequests.WithLabelValues("default", "metrics", "finish_test_run", "success")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(apiRequests.WithLabelValues("default", "metrics", "finish_test_run", "error")))
+
+	DeleteTestRunMetrics(testRunName)
+	assert.False(t, apiRequests.DeleteLabelValues("default", "metrics", "finish_test_run", "success"))
+	assert.False(t, apiRequestDuration.DeleteLabelValues("default", "metrics", "finish_test_run"))
+}
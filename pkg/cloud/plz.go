@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"go.k6.io/k6/v2/cloudapi"
+	"k8s.io/apimachinery/pkg/types"
 )
 
 const (
@@ -30,7 +31,7 @@ func RegisterPLZ(client *cloudapi.Client, data PLZRegistrationData) error {
 			Message string `json:"message"`
 		} `json:"error"`
 	}
-	if err = client.Do(req, &resp); err != nil {
+	if err = observe(types.NamespacedName{}, "register_plz", func() error { return client.Do(req, &resp) }); err != nil {
 		return fmt.Errorf("received error `%s`. Message from server `%s`", err.Error(), resp.Error.Message)
 	}
 
@@ -45,7 +46,7 @@ func DeRegisterPLZ(client *cloudapi.Client, name string) error {
 		return err
 	}
 
-	return client.Do(req, nil)
+	return observe(types.NamespacedName{}, "deregister_plz", func() error { return client.Do(req, nil) })
 }
 
 // temporary hack!
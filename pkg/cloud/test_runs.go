@@ -10,6 +10,7 @@ import (
 	"github.com/grafana/k6-operator/pkg/cloud/conn"
 	"github.com/sirupsen/logrus"
 	"go.k6.io/k6/v2/cloudapi"
+	"k8s.io/apimachinery/pkg/types"
 )
 
 type TestRunPoller struct {
@@ -55,9 +56,11 @@ func NewTestRunPoller(host, token, plzName string, logger logr.Logger) *TestRunP
 
 	testRunPoller.OnInterval = func() {
 		list, err := testRunPoller.getTestRuns(plzName)
+		plzPolls.WithLabelValues(plzName, resultLabel(err)).Inc()
 		if err != nil {
 			logger.Error(err, "Failed to get test runs from k6 Cloud.")
 		} else {
+			plzPolledTestRuns.WithLabelValues(plzName).Set(float64(len(list)))
 			logger.Info(fmt.Sprintf("Retrieved test runs: %+v", list))
 
 			ctx := testRunPoller.Context()
@@ -86,7 +89,7 @@ func (poller *TestRunPoller) getTestRuns(plzName string) ([]string, error) {
 	}
 
 	var list testRunList
-	if err = poller.Client.Do(req, &list); err != nil {
+	if err = observe(types.NamespacedName{}, "get_plz_test_runs", func() error { return poller.Client.Do(req, &list) }); err != nil {
 		return nil, err
 	}
 
@@ -98,14 +101,14 @@ func (poller *TestRunPoller) getTestRuns(plzName string) ([]string, error) {
 	return simplifiedList, nil
 }
 
-func getTestRun(client *cloudapi.Client, url string) (*TestRunData, error) {
+func getTestRun(client *cloudapi.Client, testRunName types.NamespacedName, url, operation string) (*TestRunData, error) {
 	req, err := client.NewRequest("GET", url, nil)
 	if err != nil {
 		return nil, err
 	}
 
 	var trData TestRunData
-	if err = client.Do(req, &trData); err != nil {
+	if err = observe(testRunName, operation, func() error { return client.Do(req, &trData) }); err != nil {
 		return nil, err
 	}
 
@@ -113,19 +116,19 @@ func getTestRun(client *cloudapi.Client, url string) (*TestRunData, error) {
 }
 
 // called by PLZworker
-func GetTestRunData(client *cloudapi.Client, refID string) (*TestRunData, error) {
+func GetTestRunData(client *cloudapi.Client, testRunName types.NamespacedName, refID string) (*TestRunData, error) {
 	url := fmt.Sprintf("%s/loadtests/v4/test_runs(%s)?$select=id,run_status,k8s_load_zones_config,k6_runtime_config,test_run_token,secrets_config,load_zone_distribution", strings.TrimSuffix(client.BaseURL(), "/v1"), refID)
-	return getTestRun(client, url)
+	return getTestRun(client, testRunName, url, "get_test_run_data")
 }
 
 // called by TestRun controller
 // If there's an error, it'll be logged.
-func GetTestRunState(client *cloudapi.Client, refID string, logger logr.Logger) (TestRunStatus, error) {
+func GetTestRunState(client *cloudapi.Client, testRunName types.NamespacedName, refID string, logger logr.Logger) (TestRunStatus, error) {
 	host := ApiURL(client.BaseURL())
 	logger = logger.WithValues("k6_cloud_host", host)
 
 	url := fmt.Sprintf("%s/loadtests/v4/test_runs(%s)?$select=id,run_status", host, refID)
-	trData, err := getTestRun(client, url)
+	trData, err := getTestRun(client, testRunName, url, "get_test_run_state")
 	if err != nil {
 		logger.Error(err, "Failed to get test run state.")
 		return TestRunStatus(cloudapi.RunStatusRunning), err
@@ -139,7 +142,7 @@ func GetTestRunState(client *cloudapi.Client, refID string, logger logr.Logger)
 
 // called by TestRun controller
 // If there's an error, it'll be logged.
-func SendTestRunEvents(client *cloudapi.Client, refID string, logger logr.Logger, events *Events) {
+func SendTestRunEvents(client *cloudapi.Client, testRunName types.NamespacedName, refID string, logger logr.Logger, events *Events) {
 	if len(*events) == 0 {
 		return
 	}
@@ -158,7 +161,7 @@ func SendTestRunEvents(client *cloudapi.Client, refID string, logger logr.Logger
 	logger.Info(fmt.Sprintf("Sending events to k6 Cloud %+v", *events))
 
 	// status code is checked in Do
-	if err = client.Do(req, nil); err != nil {
+	if err = observe(testRunName, "send_test_run_events", func() error { return client.Do(req, nil) }); err != nil {
 		logger.Error(err, fmt.Sprintf("Failed to send events %+v", events))
 	}
 }
@@ -89,6 +89,7 @@ func (w *PLZWorker) StopFactory() {
 	if w.poller != nil {
 		w.poller.Stop()
 	}
+	cloud.DeletePLZMetrics(w.plz.Name)
 }
 
 // createTemplate creates a default template, applicable for all PLZ test runs.
@@ -220,7 +221,7 @@ func (w *PLZWorker) handle(ctx context.Context, testRunId string) {
 
 	// Test does not exist so get its data and create it.
 
-	trData, err := cloud.GetTestRunData(w.poller.Client, testRunId)
+	trData, err := cloud.GetTestRunData(w.poller.Client, namespacedName, testRunId)
 	if err != nil {
 		w.logger.Error(err, fmt.Sprintf("Failed to retrieve test run data for `%s`", testRunId))
 		return
@@ -257,7 +258,7 @@ func (w *PLZWorker) handle(ctx context.Context, testRunId string) {
 		events := cloud.ErrorEvent(cloud.K6OperatorStartError).
 			WithDetail("k6-operator: PLZ is misconfigured (outdated TestRun CRD); upgrade the k6-operator CRDs").
 			WithAbort()
-		cloud.SendTestRunEvents(w.poller.Client, testRunId, w.logger, events)
+		cloud.SendTestRunEvents(w.poller.Client, namespacedName, testRunId, w.logger, events)
 
 		if err := w.k8sClient.Delete(ctx, tr); err != nil {
 			w.logger.Error(err, "Failed to delete the incomplete PLZ test run", "testRunId", testRunId)
@@ -0,0 +1,84 @@
+package jobs
+
+import (
+	"fmt"
+
+	"github.com/grafana/k6-operator/api/v1alpha1"
+	"github.com/grafana/k6-operator/pkg/types"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	archiveVolumeName = "k6-archive-volume"
+	archiveDir        = "/archive/"
+	archiveFilename   = "archive.tar"
+)
+
+var defaultArchiveSize = resource.MustParse("100Mi")
+
+// ArchiveClaimName is the name of the PersistentVolumeClaim with k6 archive of the TestRun.
+func ArchiveClaimName(k6 *v1alpha1.TestRun) string {
+	return fmt.Sprintf("%s-archive", k6.NamespacedName().Name)
+}
+
+// NewArchiveClaim builds the PersistentVolumeClaim where the initializer
+// stores k6 archive for the runners.
+func NewArchiveClaim(k6 *v1alpha1.TestRun) *corev1.PersistentVolumeClaim {
+	archive := k6.GetSpec().Archive
+
+	size := defaultArchiveSize
+	if archive.Size != nil {
+		size = *archive.Size
+	}
+
+	accessModes := archive.AccessModes
+	if len(accessModes) == 0 {
+		accessModes = []corev1.PersistentVolumeAccessMode{corev1.ReadWriteMany}
+	}
+
+	return &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      ArchiveClaimName(k6),
+			Namespace: k6.NamespacedName().Namespace,
+			Labels:    newLabels(k6.NamespacedName().Name),
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes:      accessModes,
+			StorageClassName: archive.StorageClassName,
+			Resources: corev1.VolumeResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: size,
+				},
+			},
+		},
+	}
+}
+
+// archiveScript describes k6 archive on the PersistentVolumeClaim
+// as the script to be run by the runners.
+func archiveScript(k6 *v1alpha1.TestRun) *types.Script {
+	return &types.Script{
+		Name:     ArchiveClaimName(k6),
+		ReadOnly: true,
+		Filename: archiveFilename,
+		Path:     archiveDir,
+		Type:     "VolumeClaim",
+	}
+}
+
+// archiveVolume is the volume of the initializer where k6 archive is written to.
+func archiveVolume(k6 *v1alpha1.TestRun) (corev1.Volume, corev1.VolumeMount) {
+	return corev1.Volume{
+		Name: archiveVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+				ClaimName: ArchiveClaimName(k6),
+			},
+		},
+	}, corev1.VolumeMount{
+		Name:      archiveVolumeName,
+		MountPath: archiveDir,
+	}
+}
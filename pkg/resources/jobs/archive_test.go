@@ -0,0 +1,124 @@
+package jobs
+
+import (
+	"slices"
+	"testing"
+
+	deep "github.com/go-test/deep"
+	"github.com/grafana/k6-operator/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func testRunWithArchive(archive *v1alpha1.ScriptArchive) *v1alpha1.TestRun {
+	return &v1alpha1.TestRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "test",
+		},
+		Spec: v1alpha1.TestRunSpec{
+			Parallelism: 1,
+			Script: v1alpha1.K6Script{
+				ConfigMap: v1alpha1.K6Configmap{
+					Name: "test",
+					File: "test.js",
+				},
+			},
+			Archive: archive,
+		},
+	}
+}
+
+func Test_NewArchiveClaim(t *testing.T) {
+	storageClass := "nfs"
+
+	tests := []struct {
+		name     string
+		archive  *v1alpha1.ScriptArchive
+		expected corev1.PersistentVolumeClaimSpec
+	}{
+		{
+			name:    "defaults",
+			archive: &v1alpha1.ScriptArchive{},
+			expected: corev1.PersistentVolumeClaimSpec{
+				AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteMany},
+				Resources: corev1.VolumeResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("100Mi")},
+				},
+			},
+		},
+		{
+			name: "custom",
+			archive: &v1alpha1.ScriptArchive{
+				StorageClassName: &storageClass,
+				Size:             resource.NewQuantity(1<<30, resource.BinarySI),
+				AccessModes:      []corev1.PersistentVolumeAccessMode{corev1.ReadOnlyMany},
+			},
+			expected: corev1.PersistentVolumeClaimSpec{
+				AccessModes:      []corev1.PersistentVolumeAccessMode{corev1.ReadOnlyMany},
+				StorageClassName: &storageClass,
+				Resources: corev1.VolumeResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceStorage: *resource.NewQuantity(1<<30, resource.BinarySI)},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claim := NewArchiveClaim(testRunWithArchive(tt.archive))
+
+			if claim.Name != "test-archive" || claim.Namespace != "test" {
+				t.Errorf("unexpected name of the claim: %s/%s", claim.Namespace, claim.Name)
+			}
+			if diff := deep.Equal(claim.Spec, tt.expected); diff != nil {
+				t.Errorf("NewArchiveClaim difference: %v", diff)
+			}
+		})
+	}
+}
+
+func Test_ArchiveIsSharedWithRunners(t *testing.T) {
+	k6 := testRunWithArchive(&v1alpha1.ScriptArchive{})
+	k6.Spec.Args = []string{"--vus", "1"}
+
+	initializer, err := NewInitializerJob(k6, []string{"--vus", "1"})
+	if err != nil {
+		t.Fatalf("NewInitializerJob errored: %v", err)
+	}
+
+	container := initializer.Spec.Template.Spec.Containers[0]
+	if !slices.Contains(container.Command, "/archive/archive.tar") {
+		t.Errorf("initializer should write the archive to the volume, got: %v", container.Command)
+	}
+	if diff := deep.Equal(container.VolumeMounts, []corev1.VolumeMount{
+		{Name: "k6-test-volume", MountPath: "/test", ReadOnly: true},
+		{Name: "k6-archive-volume", MountPath: "/archive/"},
+	}); diff != nil {
+		t.Errorf("initializer volume mounts difference: %v", diff)
+	}
+
+	runner, err := NewRunnerJob(k6, 1, nil)
+	if err != nil {
+		t.Fatalf("NewRunnerJob errored: %v", err)
+	}
+
+	container = runner.Spec.Template.Spec.Containers[0]
+	if !slices.Contains(container.Command, "/archive/archive.tar") || slices.Contains(container.Command, "/test/test.js") {
+		t.Errorf("runner should run the archive, got: %v", container.Command)
+	}
+	if diff := deep.Equal(runner.Spec.Template.Spec.Volumes, []corev1.Volume{{
+		Name: "k6-test-volume",
+		VolumeSource: corev1.VolumeSource{
+			PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "test-archive"},
+		},
+	}}); diff != nil {
+		t.Errorf("runner volumes difference: %v", diff)
+	}
+	if diff := deep.Equal(container.VolumeMounts, []corev1.VolumeMount{
+		{Name: "k6-test-volume", MountPath: "/archive/", ReadOnly: true},
+	}); diff != nil {
+		t.Errorf("runner volume mounts difference: %v", diff)
+	}
+}
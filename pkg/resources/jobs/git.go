@@ -0,0 +1,92 @@
+package jobs
+
+import (
+	"github.com/grafana/k6-operator/api/v1alpha1"
+	"github.com/grafana/k6-operator/pkg/types"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// GitContainerName is the name of the init container which clones
+	// the git repository with the script.
+	GitContainerName = "k6-git"
+
+	gitDefaultImage = "alpine/git:v2.47.2"
+)
+
+// gitCloneScript clones the repository into $1 and checks out the revision.
+// Only the revision itself is fetched when the server allows it; otherwise,
+// e.g. for a commit SHA on some servers, the whole repository is fetched.
+// The SHA of the checked out commit is written to $2, the termination message
+// of the container, so that the operator can record it. Host keys of SSH
+// servers are verified only against known_hosts from the secret.
+const gitCloneScript = `set -eu
+if [ -n "${K6_GIT_SECRET_DIR:-}" ]; then
+  if [ -f "${K6_GIT_SECRET_DIR}/ssh-privatekey" ]; then
+    # ssh refuses keys which can be read by others
+    cp "${K6_GIT_SECRET_DIR}/ssh-privatekey" "${HOME}/.k6-git-key"
+    chmod 600 "${HOME}/.k6-git-key"
+    # without known keys of the server, the connection could be intercepted
+    if [ ! -f "${K6_GIT_SECRET_DIR}/known_hosts" ]; then
+      echo "known_hosts is required next to ssh-privatekey in the secret to verify the server" | tee "$2" >&2
+      exit 1
+    fi
+    export GIT_SSH_COMMAND="ssh -i ${HOME}/.k6-git-key -o UserKnownHostsFile=${K6_GIT_SECRET_DIR}/known_hosts -o StrictHostKeyChecking=yes"
+  fi
+  if [ -f "${K6_GIT_SECRET_DIR}/token" ]; then
+    git config --global credential.helper '!f() { echo "username=$(cat "${K6_GIT_SECRET_DIR}/username" 2>/dev/null || echo git)"; echo "password=$(cat "${K6_GIT_SECRET_DIR}/token")"; }; f'
+  fi
+fi
+git config --global --add safe.directory "$1"
+cd "$1"
+git init -q .
+git remote add origin "${K6_GIT_URL}"
+revision="${K6_GIT_REVISION:-HEAD}"
+if git fetch -q --depth 1 origin "${revision}"; then
+  git checkout -q FETCH_HEAD
+else
+  git fetch -q --tags origin '+refs/heads/*:refs/remotes/origin/*'
+  git checkout -q "${revision}"
+fi
+git rev-parse HEAD | tee "$2"`
+
+// newGitContainer builds the init container which clones the git repository
+// of the script into the script volume.
+func newGitContainer(pod *v1alpha1.Pod, script *types.Script) corev1.Container {
+	image := gitDefaultImage
+	if script.Source.Image != "" {
+		image = script.Source.Image
+	}
+
+	env := []corev1.EnvVar{
+		{Name: "HOME", Value: "/tmp"},
+		{Name: "K6_GIT_URL", Value: script.Source.URL},
+		{Name: "K6_GIT_REVISION", Value: script.Source.Revision},
+	}
+
+	volumeMounts := []corev1.VolumeMount{{
+		Name:      types.ScriptVolumeName,
+		MountPath: sourceDir,
+	}}
+
+	if script.Source.SecretName != "" {
+		env = append(env, corev1.EnvVar{Name: "K6_GIT_SECRET_DIR", Value: types.SourceSecretDir})
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{
+			Name:      types.SourceSecretVolumeName,
+			MountPath: types.SourceSecretDir,
+			ReadOnly:  true,
+		})
+	}
+
+	return corev1.Container{
+		Name:                     GitContainerName,
+		Image:                    image,
+		ImagePullPolicy:          pod.ImagePullPolicy,
+		Command:                  []string{"sh", "-c", gitCloneScript, "sh", sourceDir, corev1.TerminationMessagePathDefault},
+		Env:                      env,
+		VolumeMounts:             volumeMounts,
+		SecurityContext:          &pod.ContainerSecurityContext,
+		TerminationMessagePath:   corev1.TerminationMessagePathDefault,
+		TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
+	}
+}
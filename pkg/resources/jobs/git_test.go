@@ -0,0 +1,186 @@
+package jobs
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/grafana/k6-operator/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func runGit(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+	cmd := exec.Command("git", append([]string{"-C", dir}, args...)...)
+	cmd.Env = append(os.Environ(),
+		"GIT_AUTHOR_NAME=k6", "GIT_AUTHOR_EMAIL=k6@example.com",
+		"GIT_COMMITTER_NAME=k6", "GIT_COMMITTER_EMAIL=k6@example.com")
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("git %v: %v: %s", args, err, out)
+	}
+	return strings.TrimSpace(string(out))
+}
+
+func commitFile(t *testing.T, repo, name, content string) string {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(filepath.Join(repo, name)), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(repo, name), []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	runGit(t, repo, "add", "-A")
+	runGit(t, repo, "commit", "-q", "-m", "update "+name)
+	return runGit(t, repo, "rev-parse", "HEAD")
+}
+
+func Test_gitCloneScript(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	repo := t.TempDir()
+	runGit(t, repo, "init", "-q", "-b", "main")
+	first := commitFile(t, repo, "tests/test.js", "// v1")
+	runGit(t, repo, "tag", "v1")
+	second := commitFile(t, repo, "tests/test.js", "// v2")
+	runGit(t, repo, "checkout", "-q", "-b", "feature")
+	feature := commitFile(t, repo, "tests/test.js", "// feature")
+	runGit(t, repo, "checkout", "-q", "main")
+
+	tests := []struct {
+		name           string
+		revision       string
+		expectedCommit string
+		expectedScript string
+	}{
+		{"default branch", "", second, "// v2"},
+		{"branch", "feature", feature, "// feature"},
+		{"tag", "v1", first, "// v1"},
+		{"commit", first, first, "// v1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dest := t.TempDir()
+			termination := filepath.Join(t.TempDir(), "termination-log")
+
+			cmd := exec.Command("sh", "-c", gitCloneScript, "sh", dest, termination)
+			cmd.Env = append(os.Environ(),
+				"HOME="+t.TempDir(),
+				"K6_GIT_URL=file://"+repo,
+				"K6_GIT_REVISION="+tt.revision)
+			if out, err := cmd.CombinedOutput(); err != nil {
+				t.Fatalf("clone has failed: %v: %s", err, out)
+			}
+
+			commit, err := os.ReadFile(termination)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := strings.TrimSpace(string(commit)); got != tt.expectedCommit {
+				t.Errorf("expected commit %s, got %s", tt.expectedCommit, got)
+			}
+
+			script, err := os.ReadFile(filepath.Join(dest, "tests", "test.js"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(script) != tt.expectedScript {
+				t.Errorf("expected script %q, got %q", tt.expectedScript, script)
+			}
+		})
+	}
+}
+
+func Test_NewRunnerJob_GitScript(t *testing.T) {
+	k6 := &v1alpha1.TestRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "test",
+		},
+		Spec: v1alpha1.TestRunSpec{
+			Parallelism: 1,
+			Script: v1alpha1.K6Script{
+				Git: &v1alpha1.K6GitScript{
+					URL:        "https://example.com/tests.git",
+					Ref:        "main",
+					Path:       "tests",
+					SecretName: "git-credentials",
+				},
+			},
+		},
+		Status: v1alpha1.TestRunStatus{
+			ScriptCommit: "0123456789abcdef",
+		},
+	}
+
+	job, err := NewRunnerJob(k6, 1, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	spec := job.Spec.Template.Spec
+
+	if len(spec.InitContainers) != 1 || spec.InitContainers[0].Name != GitContainerName {
+		t.Fatalf("expected the git init container, got %v", spec.InitContainers)
+	}
+	git := spec.InitContainers[0]
+	if git.Image != gitDefaultImage {
+		t.Errorf("expected image %s, got %s", gitDefaultImage, git.Image)
+	}
+
+	env := map[string]string{}
+	for _, e := range git.Env {
+		env[e.Name] = e.Value
+	}
+	// Runners check out the commit of the initializer rather than the branch.
+	if env["K6_GIT_REVISION"] != "0123456789abcdef" {
+		t.Errorf("expected the runner to check out the recorded commit, got %q", env["K6_GIT_REVISION"])
+	}
+	if env["K6_GIT_SECRET_DIR"] == "" {
+		t.Errorf("expected credentials to be passed to the git container")
+	}
+
+	var secretVolume *corev1.Volume
+	for i := range spec.Volumes {
+		if spec.Volumes[i].Secret != nil {
+			secretVolume = &spec.Volumes[i]
+		}
+	}
+	if secretVolume == nil || secretVolume.Secret.SecretName != "git-credentials" {
+		t.Errorf("expected the secret volume with credentials, got %v", spec.Volumes)
+	}
+
+	if cmd := job.Spec.Template.Spec.Containers[0].Command; !strings.Contains(strings.Join(cmd, " "), "/test/tests/test.js") {
+		t.Errorf("expected the runner to run the script from the repository, got %v", cmd)
+	}
+}
+
+func Test_gitCloneScript_RequiresKnownHosts(t *testing.T) {
+	secretDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(secretDir, "ssh-privatekey"), []byte("key"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	termination := filepath.Join(t.TempDir(), "termination-log")
+
+	cmd := exec.Command("sh", "-c", gitCloneScript, "sh", t.TempDir(), termination)
+	cmd.Env = append(os.Environ(),
+		"HOME="+t.TempDir(),
+		"K6_GIT_URL=git@example.com:tests.git",
+		"K6_GIT_SECRET_DIR="+secretDir)
+	if out, err := cmd.CombinedOutput(); err == nil {
+		t.Fatalf("clone without known_hosts should fail: %s", out)
+	}
+
+	msg, err := os.ReadFile(termination)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(msg), "known_hosts is required") {
+		t.Errorf("unexpected termination message %q", msg)
+	}
+}
@@ -117,9 +117,14 @@ func newIstioEnvVar(istio v1alpha1.K6Scuttle, istioEnabled bool) []corev1.EnvVar
 }
 
 // TODO: Envoy variables are not passed to init containers
-func getInitContainers(pod *v1alpha1.Pod, script *types.Script) []corev1.Container {
+func getInitContainers(pod *v1alpha1.Pod, script *types.Script, starterImage string) []corev1.Container {
 	var initContainers []corev1.Container
 
+	// The script is fetched first, so that other init containers see it.
+	if sourceContainer, ok := newSourceContainer(pod, script, starterImage); ok {
+		initContainers = append(initContainers, sourceContainer)
+	}
+
 	for i, k6InitContainer := range pod.InitContainers {
 
 		name := fmt.Sprintf("k6-init-%d", i)
@@ -15,7 +15,7 @@ const initializerMissingK6Message = `level=error msg="k6 executable not found in
 
 // NewInitializerJob builds a template used to create an initializer job
 func NewInitializerJob(k6 *v1alpha1.TestRun, archiveArgs []string) (*batchv1.Job, error) {
-	script, err := k6.GetSpec().ParseScript()
+	script, err := parseScript(k6)
 	if err != nil {
 		return nil, err
 	}
@@ -63,6 +63,9 @@ func NewInitializerJob(k6 *v1alpha1.TestRun, archiveArgs []string) (*batchv1.Job
 		scriptName  = script.FullName()
 		archiveName = fmt.Sprintf("/tmp/%s.archived.tar", script.Filename)
 	)
+	if k6.IsReusingArchive() {
+		archiveName = archiveScript(k6).FullName()
+	}
 	istioCommand, istioEnabled := newIstioCommand(k6.GetSpec().Scuttle.Enabled, []string{"sh", "-c"})
 
 	// NOTE: only .env are passed to k6 CLI, not .envFrom.
@@ -74,9 +77,15 @@ func NewInitializerJob(k6 *v1alpha1.TestRun, archiveArgs []string) (*batchv1.Job
 	env := append(newIstioEnvVar(k6.GetSpec().Scuttle, istioEnabled), k6.GetSpec().Initializer.Env...)
 
 	volumes := script.Volume()
-	volumes = append(volumes, k6.GetSpec().Initializer.Volumes...)
-
 	volumeMounts := script.VolumeMount()
+
+	if k6.IsReusingArchive() {
+		volume, volumeMount := archiveVolume(k6)
+		volumes = append(volumes, volume)
+		volumeMounts = append(volumeMounts, volumeMount)
+	}
+
+	volumes = append(volumes, k6.GetSpec().Initializer.Volumes...)
 	volumeMounts = append(volumeMounts, k6.GetSpec().Initializer.VolumeMounts...)
 
 	if k6.GetSpec().Initializer.SchedulerName != "" {
@@ -109,7 +118,7 @@ func NewInitializerJob(k6 *v1alpha1.TestRun, archiveArgs []string) (*batchv1.Job
 					RestartPolicy:                corev1.RestartPolicyNever,
 					SchedulerName:                schedulerName,
 					ImagePullSecrets:             k6.GetSpec().Initializer.ImagePullSecrets,
-					InitContainers:               getInitContainers(k6.GetSpec().Initializer, script),
+					InitContainers:               getInitContainers(k6.GetSpec().Initializer, script, getStarterImage(k6)),
 					Containers: []corev1.Container{
 						{
 							Image:           image,
@@ -122,6 +131,9 @@ func NewInitializerJob(k6 *v1alpha1.TestRun, archiveArgs []string) (*batchv1.Job
 							EnvFrom:         k6.GetSpec().Initializer.EnvFrom,
 							Ports:           ports,
 							SecurityContext: &k6.GetSpec().Initializer.ContainerSecurityContext,
+							// kubelet falls back to the logs if k6 could not even be executed.
+							TerminationMessagePath:   corev1.TerminationMessagePathDefault,
+							TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
 						},
 					},
 					Volumes:           volumes,
@@ -148,45 +160,53 @@ func NewInitializerJob(k6 *v1alpha1.TestRun, archiveArgs []string) (*batchv1.Job
 // d) k6 binary is missing;
 // e) k6 binary exists but is corrupted or otherwise unexecutable.
 //
-// Warnings at this point are not necessary (warning messages will re-appear in
-// runner's logs and the user can see them there) so we need a pure JSON here,
-// without any additional messages in cases a) and b). In cases c) - e), output
-// should contain an error message and the Job is to exit with non-zero code.
+// The result is passed to the operator via the termination message of
+// the container: the compacted JSON in cases a) and b), and the error
+// messages in cases c) - e), where the Job is to exit with non-zero code.
+// Warnings are not necessary at this point (warning messages will re-appear
+// in runner's logs and the user can see them there).
 //
-// Due to some peculiarities of k6 logging, to achieve the above behaviour,
-// we need to use a workaround to store all log messages in temp file while
-// printing JSON as usual. Then parse temp file only for errors, ignoring
-// any other log messages.
+// Due to some peculiarities of k6 logging, to separate the errors from
+// the JSON, we need to use a workaround to store all log messages in temp file.
+// Then parse temp file only for errors, ignoring any other log messages.
 // Related: https://github.com/grafana/k6-docs/issues/877
+//
+// The pure JSON is still printed to the logs, for the cases when the termination
+// message is truncated by kubelet.
 func initializerShellScript(setup, archiveRef, archiveCmd string) string {
 	if setup != "" {
 		setup += "\n"
 	}
 	return fmt.Sprintf(
 		`if ! command -v k6 >/dev/null 2>&1; then
-  echo '%[1]s' >&2
+  echo '%[1]s' | tee /dev/termination-log >&2
   exit 127
 fi
 
 logs=/tmp/k6logs
+inspect=/tmp/k6inspect.json
 %[2]s
 if ! mkdir -p "$(dirname %[3]s)"; then
   exit 1
 fi
 
 if ! %[4]s 2> "${logs}"; then
-  cat "${logs}"
+  tee /dev/termination-log < "${logs}"
   exit 1
 fi
 
-if ! k6 inspect --execution-requirements %[3]s 2> "${logs}"; then
-  cat "${logs}"
+if ! k6 inspect --execution-requirements %[3]s > "${inspect}" 2> "${logs}"; then
+  tee /dev/termination-log < "${logs}"
   exit 1
 fi
 
-if grep 'level.*error' "${logs}"; then
+if grep 'level.*error' "${logs}" > "${logs}.errors"; then
+  tee /dev/termination-log < "${logs}.errors"
   exit 1
-fi`,
+fi
+
+cat "${inspect}"
+sed 's/^ *//' "${inspect}" | tr -d '\n' > /dev/termination-log`,
 		initializerMissingK6Message,
 		setup,
 		archiveRef,
@@ -119,10 +119,12 @@ func defaultExpectedJobForInitializer() *batchv1.Job {
 									},
 								},
 							},
-							Resources:       corev1.ResourceRequirements{},
-							VolumeMounts:    script.VolumeMount(),
-							Ports:           []corev1.ContainerPort{{ContainerPort: 6565}},
-							SecurityContext: &corev1.SecurityContext{},
+							Resources:                corev1.ResourceRequirements{},
+							VolumeMounts:             script.VolumeMount(),
+							Ports:                    []corev1.ContainerPort{{ContainerPort: 6565}},
+							SecurityContext:          &corev1.SecurityContext{},
+							TerminationMessagePath:   corev1.TerminationMessagePathDefault,
+							TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
 						},
 					},
 					Volumes: script.Volume(),
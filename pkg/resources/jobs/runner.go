@@ -42,11 +42,23 @@ func NewRunnerJob(k6 *v1alpha1.TestRun, index int, tokenInfo *cloud.TokenInfo) (
 		command = append(command, args...)
 	}
 
-	script, err := k6.GetSpec().ParseScript()
+	script, err := parseScript(k6)
 	if err != nil {
 		return nil, err
 	}
 
+	// Runners must check out the same commit as the initializer, even if
+	// the branch has moved on since then.
+	if script.Type == "Git" && len(k6.GetStatus().ScriptCommit) > 0 {
+		script.Source.Revision = k6.GetStatus().ScriptCommit
+	}
+
+	// The archive contains the script with all its dependencies,
+	// so the original source of the script is not needed.
+	if k6.IsReusingArchive() {
+		script = archiveScript(k6)
+	}
+
 	command = append(command, k6.GetSpec().Argv()...)
 
 	command = append(
@@ -55,8 +67,8 @@ func NewRunnerJob(k6 *v1alpha1.TestRun, index int, tokenInfo *cloud.TokenInfo) (
 		"--address=0.0.0.0:6565")
 
 	paused := true
-	if k6.GetSpec().Paused != "" {
-		paused, _ = strconv.ParseBool(k6.GetSpec().Paused)
+	if k6.GetPaused() != "" {
+		paused, _ = strconv.ParseBool(k6.GetPaused())
 	}
 
 	if paused {
@@ -69,8 +81,17 @@ func NewRunnerJob(k6 *v1alpha1.TestRun, index int, tokenInfo *cloud.TokenInfo) (
 	// Add a testrun name tag: in case metrics are stored, they need to be distinguished by test run name
 	command = append(command, "--tag", fmt.Sprintf("testrun_name=%s", k6.NamespacedName().Name))
 
-	if v1alpha1.IsTrue(k6, v1alpha1.CloudPLZTestRun) {
-		command = append(command, "--no-setup", "--no-teardown", "--linger")
+	if k6.IsSetupTeardownOnce() {
+		command = append(command, "--no-setup", "--no-teardown")
+	}
+
+	if k6.IsLingering() {
+		command = append(command, "--linger")
+	}
+
+	// Thresholds are evaluated by the operator across all runners.
+	if k6.IsEvaluatingThresholds() {
+		command = append(command, "--no-thresholds")
 	}
 
 	// For PLZ tests, we add a reserved env var containing instance ID.
@@ -189,7 +210,7 @@ func NewRunnerJob(k6 *v1alpha1.TestRun, index int, tokenInfo *cloud.TokenInfo) (
 					TopologySpreadConstraints:    k6.GetSpec().Runner.TopologySpreadConstraints,
 					SecurityContext:              &k6.GetSpec().Runner.SecurityContext,
 					ImagePullSecrets:             k6.GetSpec().Runner.ImagePullSecrets,
-					InitContainers:               getInitContainers(&k6.GetSpec().Runner, script),
+					InitContainers:               getInitContainers(&k6.GetSpec().Runner, script, getStarterImage(k6)),
 					Containers: []corev1.Container{{
 						Image:           image,
 						ImagePullPolicy: k6.GetSpec().Runner.ImagePullPolicy,
@@ -491,6 +491,45 @@ func Test_NewRunnerJob(t *testing.T) {
 				j.Spec.Template.Spec.Affinity = newAntiAffinity()
 			},
 		},
+		{
+			name: "setup and teardown once",
+			setupTestRun: func(k6 *v1alpha1.TestRun) {
+				k6.Spec.SetupTeardown = v1alpha1.SetupTeardownOnce
+			},
+			setupExpectedJob: func(j *batchv1.Job) {
+				j.Spec.Template.Spec.Containers[0].Command = []string{
+					"k6", "run", "--quiet", "/test/test.js", "--address=0.0.0.0:6565", "--paused",
+					"--tag", "instance_id=1", "--tag", "testrun_name=test",
+					"--no-setup", "--no-teardown", "--linger",
+				}
+			},
+		},
+		{
+			name: "collecting summary keeps runners alive",
+			setupTestRun: func(k6 *v1alpha1.TestRun) {
+				k6.Spec.CollectSummary = true
+			},
+			setupExpectedJob: func(j *batchv1.Job) {
+				j.Spec.Template.Spec.Containers[0].Command = []string{
+					"k6", "run", "--quiet", "/test/test.js", "--address=0.0.0.0:6565", "--paused",
+					"--tag", "instance_id=1", "--tag", "testrun_name=test",
+					"--linger",
+				}
+			},
+		},
+		{
+			name: "thresholds evaluated by the operator",
+			setupTestRun: func(k6 *v1alpha1.TestRun) {
+				k6.Spec.EvaluateThresholds = true
+			},
+			setupExpectedJob: func(j *batchv1.Job) {
+				j.Spec.Template.Spec.Containers[0].Command = []string{
+					"k6", "run", "--quiet", "/test/test.js", "--address=0.0.0.0:6565", "--paused",
+					"--tag", "instance_id=1", "--tag", "testrun_name=test",
+					"--linger", "--no-thresholds",
+				}
+			},
+		},
 		{
 			name:      "PLZ test run",
 			tokenInfo: cloud.NewTokenInfo("plz-token-secret", "test"),
@@ -0,0 +1,46 @@
+package jobs
+
+import (
+	"fmt"
+
+	"github.com/grafana/k6-operator/api/v1alpha1"
+	"github.com/grafana/k6-operator/pkg/types"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ScriptCopyName is the name of the ConfigMap with the copy of the script
+// referenced from another namespace.
+func ScriptCopyName(k6 *v1alpha1.TestRun) string {
+	return fmt.Sprintf("%s-script", k6.NamespacedName().Name)
+}
+
+// NewScriptCopy builds the copy of the script ConfigMap in the namespace of the TestRun.
+func NewScriptCopy(k6 *v1alpha1.TestRun, source *corev1.ConfigMap) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      ScriptCopyName(k6),
+			Namespace: k6.NamespacedName().Namespace,
+			Labels:    newLabels(k6.NamespacedName().Name),
+			Annotations: map[string]string{
+				"k6.io/script-source": fmt.Sprintf("%s/%s", source.Namespace, source.Name),
+			},
+		},
+		Data:       source.Data,
+		BinaryData: source.BinaryData,
+	}
+}
+
+// parseScript parses the script of the TestRun. A ConfigMap from another
+// namespace is replaced with its copy, since Pods can mount only ConfigMaps
+// from their own namespace.
+func parseScript(k6 *v1alpha1.TestRun) (*types.Script, error) {
+	script, err := k6.GetSpec().ParseScript()
+	if err != nil {
+		return nil, err
+	}
+	if k6.IsReferencingScript() {
+		script.Name = ScriptCopyName(k6)
+	}
+	return script, nil
+}
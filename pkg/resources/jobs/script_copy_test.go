@@ -0,0 +1,53 @@
+package jobs
+
+import (
+	"testing"
+
+	"github.com/grafana/k6-operator/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_NewRunnerJob_ScriptCopy(t *testing.T) {
+	tests := []struct {
+		name      string
+		namespace string
+		expected  string
+	}{
+		{"same namespace", "", "shared"},
+		{"same namespace set explicitly", "test", "shared"},
+		{"another namespace", "scripts", "test-script"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k6 := &v1alpha1.TestRun{
+				ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "test"},
+				Spec: v1alpha1.TestRunSpec{
+					Parallelism: 1,
+					Script: v1alpha1.K6Script{
+						ConfigMap: v1alpha1.K6Configmap{Name: "shared", Namespace: tt.namespace},
+					},
+				},
+			}
+
+			job, err := NewRunnerJob(k6, 1, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var volume *corev1.Volume
+			for i := range job.Spec.Template.Spec.Volumes {
+				if job.Spec.Template.Spec.Volumes[i].Name == "k6-test-volume" {
+					volume = &job.Spec.Template.Spec.Volumes[i]
+				}
+			}
+			if volume == nil || volume.ConfigMap == nil {
+				t.Fatalf("expected the script volume from a ConfigMap, got %v", job.Spec.Template.Spec.Volumes)
+			}
+			if volume.ConfigMap.Name != tt.expected {
+				t.Errorf("expected ConfigMap %s to be mounted, got %s", tt.expected, volume.ConfigMap.Name)
+			}
+		})
+	}
+}
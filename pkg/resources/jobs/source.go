@@ -0,0 +1,207 @@
+package jobs
+
+import (
+	"fmt"
+
+	"github.com/grafana/k6-operator/api/v1alpha1"
+	"github.com/grafana/k6-operator/pkg/types"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// DownloadContainerName is the name of the init container which
+	// downloads the script by HTTP(S) or from an OCI registry.
+	DownloadContainerName = "k6-download"
+
+	// UnpackContainerName is the name of the init container which lays out
+	// an inline script or a bundle in the script volume.
+	UnpackContainerName = "k6-unpack"
+
+	// ChecksumMismatchExitCode is the exit code of the download container
+	// when the checksum of the downloaded script is not the expected one.
+	ChecksumMismatchExitCode = 3
+
+	ociDefaultImage = "ghcr.io/oras-project/oras:v1.2.0"
+
+	bundleDir = "/bundle"
+
+	// sourceDir is where the script volume is mounted in the init container
+	// fetching the script.
+	sourceDir = "/test"
+)
+
+// httpDownloadScript downloads the file into $1 and verifies its checksum.
+// Each file in the secret directory is sent as a header, with the file name
+// as the name of the header. If the checksum does not match, the error
+// is written to $2, the termination message of the container.
+var httpDownloadScript = fmt.Sprintf(`set -eu
+dest="$1"
+termination="$2"
+set --
+if [ -n "${K6_HTTP_HEADERS_DIR:-}" ]; then
+  for header in "${K6_HTTP_HEADERS_DIR}"/*; do
+    [ -f "${header}" ] || continue
+    set -- "$@" -H "$(basename "${header}"): $(cat "${header}")"
+  done
+fi
+curl -fsSL --retry 3 "$@" -o "${dest}" "${K6_HTTP_URL}"
+if [ -n "${K6_HTTP_SHA256:-}" ]; then
+  actual="$(sha256sum "${dest}" | cut -d ' ' -f 1)"
+  if [ "${actual}" != "${K6_HTTP_SHA256}" ]; then
+    echo "checksum mismatch: expected sha256 ${K6_HTTP_SHA256}, got ${actual}" | tee "${termination}"
+    exit %d
+  fi
+fi`, ChecksumMismatchExitCode)
+
+// ociPullScript pulls the files of the OCI artifact into $1. A digest in
+// the reference is verified by oras itself.
+const ociPullScript = `set -eu
+cd "$1"
+set --
+if [ -n "${K6_OCI_SECRET_DIR:-}" ]; then
+  set -- --registry-config "${K6_OCI_SECRET_DIR}/.dockerconfigjson"
+fi
+oras pull "$@" "${K6_OCI_REFERENCE}"`
+
+// inlineScript writes the inline script into $1.
+const inlineScript = `printf '%s' "${K6_INLINE_SCRIPT}" > "$1"`
+
+// bundleScript lays out the bundle in $1. The rest of the arguments are
+// triples of the folder where the item is mounted, the folder of the bundle
+// and the key with an archive, which is empty if the keys are copied as is.
+const bundleScript = `set -eu
+dest="$1"
+shift
+while [ $# -ge 3 ]; do
+  mkdir -p "${dest}/$2"
+  if [ -n "$3" ]; then
+    tar -xzf "$1/$3" -C "${dest}/$2"
+  else
+    # the keys are symlinks to a hidden folder, which is not copied
+    for key in "$1"/*; do
+      if [ -f "${key}" ]; then cp -L "${key}" "${dest}/$2/"; fi
+    done
+  fi
+  shift 3
+done
+find "${dest}" -type f`
+
+// newSourceContainer builds the init container which fetches the script
+// into the script volume, if the script needs one. Downloads by HTTP(S) and
+// unpacking are done with the starter image.
+func newSourceContainer(pod *v1alpha1.Pod, script *types.Script, starterImage string) (corev1.Container, bool) {
+	switch script.Type {
+	case "Git":
+		return newGitContainer(pod, script), true
+	case "HTTP":
+		return newHTTPContainer(pod, script, starterImage), true
+	case "OCI":
+		return newOCIContainer(pod, script), true
+	case "Inline":
+		return newInlineContainer(pod, script, starterImage), true
+	case "Bundle":
+		return newBundleContainer(pod, script, starterImage), true
+	default:
+		return corev1.Container{}, false
+	}
+}
+
+func newHTTPContainer(pod *v1alpha1.Pod, script *types.Script, starterImage string) corev1.Container {
+	env := []corev1.EnvVar{
+		{Name: "K6_HTTP_URL", Value: script.Source.URL},
+		{Name: "K6_HTTP_SHA256", Value: script.Source.SHA256},
+	}
+	if script.Source.SecretName != "" {
+		env = append(env, corev1.EnvVar{Name: "K6_HTTP_HEADERS_DIR", Value: types.SourceSecretDir})
+	}
+
+	// The starter image has curl and sha256sum.
+	return newDownloadContainer(pod, script, starterImage, env,
+		[]string{"sh", "-c", httpDownloadScript, "sh", script.FullName(), corev1.TerminationMessagePathDefault})
+}
+
+func newOCIContainer(pod *v1alpha1.Pod, script *types.Script) corev1.Container {
+	env := []corev1.EnvVar{
+		// oras keeps its cache and credentials in the home directory.
+		{Name: "HOME", Value: "/tmp"},
+		{Name: "K6_OCI_REFERENCE", Value: script.Source.URL},
+	}
+	if script.Source.SecretName != "" {
+		env = append(env, corev1.EnvVar{Name: "K6_OCI_SECRET_DIR", Value: types.SourceSecretDir})
+	}
+
+	return newDownloadContainer(pod, script, ociDefaultImage, env,
+		[]string{"sh", "-c", ociPullScript, "sh", sourceDir})
+}
+
+func newInlineContainer(pod *v1alpha1.Pod, script *types.Script, starterImage string) corev1.Container {
+	return newUnpackContainer(pod, starterImage,
+		[]corev1.EnvVar{{Name: "K6_INLINE_SCRIPT", Value: script.Inline}},
+		nil,
+		[]string{"sh", "-c", inlineScript, "sh", script.FullName()})
+}
+
+func newBundleContainer(pod *v1alpha1.Pod, script *types.Script, starterImage string) corev1.Container {
+	command := []string{"sh", "-c", bundleScript, "sh", sourceDir}
+	var volumeMounts []corev1.VolumeMount
+	for i, item := range script.Bundle {
+		mountPath := fmt.Sprintf("%s/%d", bundleDir, i)
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{
+			Name:      types.BundleVolumeName(i),
+			MountPath: mountPath,
+			ReadOnly:  true,
+		})
+		command = append(command, mountPath, item.Dir, item.Archive)
+	}
+
+	return newUnpackContainer(pod, starterImage, nil, volumeMounts, command)
+}
+
+func newUnpackContainer(pod *v1alpha1.Pod, image string, env []corev1.EnvVar, volumeMounts []corev1.VolumeMount, command []string) corev1.Container {
+	volumeMounts = append([]corev1.VolumeMount{{
+		Name:      types.ScriptVolumeName,
+		MountPath: sourceDir,
+	}}, volumeMounts...)
+
+	return corev1.Container{
+		Name:                     UnpackContainerName,
+		Image:                    image,
+		ImagePullPolicy:          pod.ImagePullPolicy,
+		Command:                  command,
+		Env:                      env,
+		VolumeMounts:             volumeMounts,
+		SecurityContext:          &pod.ContainerSecurityContext,
+		TerminationMessagePath:   corev1.TerminationMessagePathDefault,
+		TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
+	}
+}
+
+func newDownloadContainer(pod *v1alpha1.Pod, script *types.Script, image string, env []corev1.EnvVar, command []string) corev1.Container {
+	if script.Source.Image != "" {
+		image = script.Source.Image
+	}
+
+	volumeMounts := []corev1.VolumeMount{{
+		Name:      types.ScriptVolumeName,
+		MountPath: sourceDir,
+	}}
+	if script.Source.SecretName != "" {
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{
+			Name:      types.SourceSecretVolumeName,
+			MountPath: types.SourceSecretDir,
+			ReadOnly:  true,
+		})
+	}
+
+	return corev1.Container{
+		Name:                     DownloadContainerName,
+		Image:                    image,
+		ImagePullPolicy:          pod.ImagePullPolicy,
+		Command:                  command,
+		Env:                      env,
+		VolumeMounts:             volumeMounts,
+		SecurityContext:          &pod.ContainerSecurityContext,
+		TerminationMessagePath:   corev1.TerminationMessagePathDefault,
+		TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
+	}
+}
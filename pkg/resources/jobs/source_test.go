@@ -0,0 +1,286 @@
+package jobs
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/grafana/k6-operator/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_httpDownloadScript(t *testing.T) {
+	for _, tool := range []string{"curl", "sha256sum"} {
+		if _, err := exec.LookPath(tool); err != nil {
+			t.Skipf("%s is not installed", tool)
+		}
+	}
+
+	const script = "export default function () {}"
+	sum := sha256.Sum256([]byte(script))
+	checksum := hex.EncodeToString(sum[:])
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte(script))
+	}))
+	defer server.Close()
+
+	headers := t.TempDir()
+	if err := os.WriteFile(filepath.Join(headers, "Authorization"), []byte("Bearer secret"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name             string
+		headersDir       string
+		checksum         string
+		expectedExitCode int
+		expectedMessage  string
+	}{
+		{"without checksum", headers, "", 0, ""},
+		{"matching checksum", headers, checksum, 0, ""},
+		{"checksum mismatch", headers, strings.Repeat("0", 64), ChecksumMismatchExitCode, "checksum mismatch: expected sha256 " + strings.Repeat("0", 64) + ", got " + checksum},
+		{"without headers", "", "", 22, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dest := filepath.Join(t.TempDir(), "test.js")
+			termination := filepath.Join(t.TempDir(), "termination-log")
+
+			cmd := exec.Command("sh", "-c", httpDownloadScript, "sh", dest, termination)
+			cmd.Env = append(os.Environ(),
+				"K6_HTTP_URL="+server.URL+"/test.js",
+				"K6_HTTP_SHA256="+tt.checksum,
+				"K6_HTTP_HEADERS_DIR="+tt.headersDir)
+			err := cmd.Run()
+
+			exitCode := 0
+			var exitErr *exec.ExitError
+			if errors.As(err, &exitErr) {
+				exitCode = exitErr.ExitCode()
+			} else if err != nil {
+				t.Fatal(err)
+			}
+			if exitCode != tt.expectedExitCode {
+				t.Fatalf("expected exit code %d, got %d", tt.expectedExitCode, exitCode)
+			}
+
+			if tt.expectedExitCode == 0 {
+				downloaded, err := os.ReadFile(dest)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if string(downloaded) != script {
+					t.Errorf("expected script %q, got %q", script, downloaded)
+				}
+			}
+
+			if len(tt.expectedMessage) > 0 {
+				message, err := os.ReadFile(termination)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if got := strings.TrimSpace(string(message)); got != tt.expectedMessage {
+					t.Errorf("expected termination message %q, got %q", tt.expectedMessage, got)
+				}
+			}
+		})
+	}
+}
+
+func Test_NewInitializerJob_OCIScript(t *testing.T) {
+	k6 := &v1alpha1.TestRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "test",
+		},
+		Spec: v1alpha1.TestRunSpec{
+			Parallelism: 1,
+			Script: v1alpha1.K6Script{
+				OCI: &v1alpha1.K6OCIScript{
+					Reference:  "registry.example.com/tests/api:v1",
+					File:       "api/main.js",
+					SecretName: "registry-credentials",
+				},
+			},
+		},
+	}
+
+	job, err := NewInitializerJob(k6, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	spec := job.Spec.Template.Spec
+
+	if len(spec.InitContainers) != 1 || spec.InitContainers[0].Name != DownloadContainerName {
+		t.Fatalf("expected the download init container, got %v", spec.InitContainers)
+	}
+	download := spec.InitContainers[0]
+	if download.Image != ociDefaultImage {
+		t.Errorf("expected image %s, got %s", ociDefaultImage, download.Image)
+	}
+
+	env := map[string]string{}
+	for _, e := range download.Env {
+		env[e.Name] = e.Value
+	}
+	if env["K6_OCI_REFERENCE"] != "registry.example.com/tests/api:v1" {
+		t.Errorf("expected the reference of the artifact, got %q", env["K6_OCI_REFERENCE"])
+	}
+	if env["K6_OCI_SECRET_DIR"] == "" {
+		t.Errorf("expected credentials to be passed to the download container")
+	}
+
+	if cmd := spec.Containers[0].Command; !strings.Contains(strings.Join(cmd, " "), "/test/api/main.js") {
+		t.Errorf("expected the initializer to inspect the script from the artifact, got %v", cmd)
+	}
+}
+
+func Test_NewRunnerJob_StarterImage(t *testing.T) {
+	tests := []struct {
+		name          string
+		script        v1alpha1.K6Script
+		starterImage  string
+		container     string
+		expectedImage string
+	}{
+		{"inline script", v1alpha1.K6Script{Inline: "export default function () {}"}, "", UnpackContainerName, starterDefaultImage},
+		{"inline script with starter image", v1alpha1.K6Script{Inline: "export default function () {}"}, "registry.example.com/starter:v1", UnpackContainerName, "registry.example.com/starter:v1"},
+		{"HTTP script with starter image", v1alpha1.K6Script{HTTP: &v1alpha1.K6HTTPScript{URL: "https://example.com/test.js"}}, "registry.example.com/starter:v1", DownloadContainerName, "registry.example.com/starter:v1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k6 := &v1alpha1.TestRun{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test",
+					Namespace: "test",
+				},
+				Spec: v1alpha1.TestRunSpec{
+					Parallelism: 1,
+					Script:      tt.script,
+					Starter:     v1alpha1.Pod{Image: tt.starterImage},
+				},
+			}
+
+			job, err := NewRunnerJob(k6, 1, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			initContainers := job.Spec.Template.Spec.InitContainers
+
+			if len(initContainers) != 1 || initContainers[0].Name != tt.container {
+				t.Fatalf("expected the %s init container, got %v", tt.container, initContainers)
+			}
+			if initContainers[0].Image != tt.expectedImage {
+				t.Errorf("expected image %s, got %s", tt.expectedImage, initContainers[0].Image)
+			}
+		})
+	}
+}
+
+// mountedConfigMap lays out files the way kubelet mounts a ConfigMap:
+// keys are symlinks to a hidden folder with the data.
+func mountedConfigMap(t *testing.T, files map[string][]byte) string {
+	t.Helper()
+	dir := t.TempDir()
+	data := filepath.Join(dir, "..data")
+	if err := os.Mkdir(data, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	for key, content := range files {
+		if err := os.WriteFile(filepath.Join(data, key), content, 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Symlink(filepath.Join("..data", key), filepath.Join(dir, key)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func Test_bundleScript(t *testing.T) {
+	if _, err := exec.LookPath("tar"); err != nil {
+		t.Skip("tar is not installed")
+	}
+
+	// The archive with lib/helpers.js is built the way a user would do it.
+	src := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(src, "lib"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(src, "lib", "helpers.js"), []byte("export const a = 1"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	archive := filepath.Join(t.TempDir(), "modules.tar.gz")
+	if out, err := exec.Command("tar", "-czf", archive, "-C", src, "lib").CombinedOutput(); err != nil {
+		t.Fatalf("tar: %v: %s", err, out)
+	}
+	archiveContent, err := os.ReadFile(archive)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	main := mountedConfigMap(t, map[string][]byte{"test.js": []byte("import { a } from './lib/helpers.js'")})
+	data := mountedConfigMap(t, map[string][]byte{"users.json": []byte("[]")})
+	modules := mountedConfigMap(t, map[string][]byte{"modules.tar.gz": archiveContent})
+
+	dest := t.TempDir()
+	cmd := exec.Command("sh", "-c", bundleScript, "sh", dest,
+		main, "", "",
+		data, "data/users", "",
+		modules, "", "modules.tar.gz")
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("bundle has failed: %v: %s", err, out)
+	}
+
+	expected := map[string]string{
+		"test.js":               "import { a } from './lib/helpers.js'",
+		"data/users/users.json": "[]",
+		"lib/helpers.js":        "export const a = 1",
+	}
+	for file, content := range expected {
+		got, err := os.ReadFile(filepath.Join(dest, file))
+		if err != nil {
+			t.Errorf("expected %s in the bundle: %v", file, err)
+			continue
+		}
+		if string(got) != content {
+			t.Errorf("expected %s to be %q, got %q", file, content, got)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(dest, "..data")); !os.IsNotExist(err) {
+		t.Errorf("expected the hidden folder of the ConfigMap not to be copied")
+	}
+}
+
+func Test_inlineScript(t *testing.T) {
+	const script = "import http from 'k6/http';\nexport default function () { http.get('https://example.com/?a=%s') }\n"
+
+	dest := filepath.Join(t.TempDir(), "test.js")
+	cmd := exec.Command("sh", "-c", inlineScript, "sh", dest)
+	cmd.Env = append(os.Environ(), "K6_INLINE_SCRIPT="+script)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("inline script has failed: %v: %s", err, out)
+	}
+
+	got, err := os.ReadFile(dest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != script {
+		t.Errorf("expected script %q, got %q", script, got)
+	}
+}
@@ -12,11 +12,23 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+// starterDefaultImage is the image of the starter. It is also used by
+// the init containers which download or unpack the script.
+const starterDefaultImage = "ghcr.io/grafana/k6-operator:latest-starter"
+
+// getStarterImage returns .spec.starter.image if set, or the default image.
+func getStarterImage(k6 *v1alpha1.TestRun) string {
+	if k6.GetSpec().Starter.Image != "" {
+		return k6.GetSpec().Starter.Image
+	}
+	return starterDefaultImage
+}
+
 // NewStarterJob builds a template used for creating a starter job
 func NewStarterJob(k6 *v1alpha1.TestRun, hostname []string) *batchv1.Job {
 
 	var (
-		starterImage                 = "ghcr.io/grafana/k6-operator:latest-starter"
+		starterImage                 = getStarterImage(k6)
 		starterAnnotations           = make(map[string]string)
 		starterLabels                = newLabels(k6.NamespacedName().Name)
 		serviceAccountName           = "default"
@@ -39,10 +51,6 @@ func NewStarterJob(k6 *v1alpha1.TestRun, hostname []string) *batchv1.Job {
 		starterAnnotations = k6.GetSpec().Starter.Metadata.Annotations
 	}
 
-	if k6.GetSpec().Starter.Image != "" {
-		starterImage = k6.GetSpec().Starter.Image
-	}
-
 	if k6.GetSpec().Starter.Metadata.Labels != nil {
 		for k, v := range k6.GetSpec().Starter.Metadata.Labels { // Order not specified
 			if _, ok := starterLabels[k]; !ok {
@@ -4,20 +4,16 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
-	"fmt"
-	"net/http"
 	"net/url"
 
 	"github.com/grafana/k6-operator/pkg/types"
-	k6Client "go.k6.io/k6/v2/api/v1/client"
+	k6api "go.k6.io/k6/v2/api/v1"
 )
 
 // This will probably be removed once distributed mode in k6 is implemented.
 
-func RunSetup(ctx context.Context, hostname string) (_ json.RawMessage, err error) {
-	c, err := k6Client.New(fmt.Sprintf("%v:6565", hostname), k6Client.WithHTTPClient(&http.Client{
-		Timeout: 0,
-	}))
+func RunSetup(ctx context.Context, t Transport, runner Runner) (_ json.RawMessage, err error) {
+	c, err := t.Client(runner, SetupTimeout)
 	if err != nil {
 		return
 	}
@@ -37,11 +33,9 @@ func RunSetup(ctx context.Context, hostname string) (_ json.RawMessage, err erro
 	return response.Data.Attributes.Data, nil
 }
 
-func SetSetupData(ctx context.Context, hostnames []string, data json.RawMessage) (err error) {
-	for _, hostname := range hostnames {
-		c, err := k6Client.New(fmt.Sprintf("%v:6565", hostname), k6Client.WithHTTPClient(&http.Client{
-			Timeout: 0,
-		}))
+func SetSetupData(ctx context.Context, t Transport, runners []Runner, data json.RawMessage) (err error) {
+	for _, runner := range runners {
+		c, err := t.Client(runner, RequestTimeout)
 		if err != nil {
 			return err
 		}
@@ -54,17 +48,62 @@ func SetSetupData(ctx context.Context, hostnames []string, data json.RawMessage)
 	return nil
 }
 
-func RunTeardown(ctx context.Context, hostnames []string) (err error) {
-	if len(hostnames) == 0 {
-		return errors.New("no k6 Service is available to run teardown")
+func RunTeardown(ctx context.Context, t Transport, runners []Runner) (err error) {
+	if len(runners) == 0 {
+		return errors.New("no k6 runner is available to run teardown")
 	}
 
-	c, err := k6Client.New(fmt.Sprintf("%v:6565", hostnames[0]), k6Client.WithHTTPClient(&http.Client{
-		Timeout: 0,
-	}))
+	c, err := t.Client(runners[0], SetupTimeout)
 	if err != nil {
 		return
 	}
 
 	return c.CallAPI(ctx, "POST", &url.URL{Path: "/v1/teardown"}, nil, nil)
 }
+
+// SetPaused pauses or resumes the test execution on all runners.
+func SetPaused(ctx context.Context, t Transport, runners []Runner, paused bool) (err error) {
+	req := types.StatusAPIRequest{
+		Data: types.StatusAPIRequestData{
+			Attributes: types.StatusAPIRequestDataAttributes{
+				Paused: paused,
+			},
+			ID:   "default",
+			Type: "status",
+		},
+	}
+
+	for _, runner := range runners {
+		c, err := t.Client(runner, RequestTimeout)
+		if err != nil {
+			return err
+		}
+
+		if err = c.CallAPI(ctx, "PATCH", &url.URL{Path: "/v1/status"}, req, nil); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// GetMetrics retrieves current values of all metrics from the runner.
+// Once the test has ended, these are the values of the end-of-test summary.
+func GetMetrics(ctx context.Context, t Transport, runner Runner) ([]k6api.Metric, error) {
+	c, err := t.Client(runner, RequestTimeout)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.Metrics(ctx)
+}
+
+// GetStatus retrieves the execution status of the test from the runner.
+func GetStatus(ctx context.Context, t Transport, runner Runner) (k6api.Status, error) {
+	c, err := t.Client(runner, RequestTimeout)
+	if err != nil {
+		return k6api.Status{}, err
+	}
+
+	return c.Status(ctx)
+}
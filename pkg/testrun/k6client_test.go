@@ -10,13 +10,18 @@ import (
 )
 
 func Test_RunTeardownNoHost(t *testing.T) {
-	err := RunTeardown(context.Background(), []string{})
+	err := RunTeardown(context.Background(), DirectTransport{}, nil)
 	require.Error(t, err)
-	assert.Contains(t, err.Error(), "no k6 Service is available to run teardown")
+	assert.Contains(t, err.Error(), "no k6 runner is available to run teardown")
 }
 
 func Test_SetSetupDataNoHost(t *testing.T) {
 	data := json.RawMessage(`{"foo":"bar"}`)
-	err := SetSetupData(context.Background(), []string{}, data)
+	err := SetSetupData(context.Background(), DirectTransport{}, nil, data)
+	assert.NoError(t, err)
+}
+
+func Test_SetPausedNoHost(t *testing.T) {
+	err := SetPaused(context.Background(), DirectTransport{}, nil, true)
 	assert.NoError(t, err)
 }
@@ -0,0 +1,83 @@
+package testrun
+
+import (
+	"math"
+
+	k6api "go.k6.io/k6/v2/api/v1"
+	"go.k6.io/k6/v2/metrics"
+)
+
+// MetricSummary is the end-of-test value of a single metric.
+type MetricSummary struct {
+	Type   string             `json:"type"`
+	Values map[string]float64 `json:"values"`
+}
+
+// Summary maps names of metrics to their end-of-test values.
+type Summary map[string]MetricSummary
+
+// NewSummary converts metrics from k6 REST API to Summary.
+func NewSummary(list []k6api.Metric) Summary {
+	summary := make(Summary, len(list))
+	for _, m := range list {
+		if !m.Type.Valid {
+			continue
+		}
+		summary[m.Name] = MetricSummary{
+			Type:   m.Type.Type.String(),
+			Values: m.Sample,
+		}
+	}
+	return summary
+}
+
+// CombineSummaries builds a summary across all runners. Only the values
+// which can be combined exactly are included: counters are summed, the
+// maximum of gauges and the minimum and maximum of trends are taken.
+// Rates and percentiles of trends depend on the number of samples on
+// each runner, so they are left out.
+func CombineSummaries(summaries []Summary) Summary {
+	combined := Summary{}
+
+	for _, summary := range summaries {
+		for name, m := range summary {
+			c, ok := combined[name]
+			if !ok {
+				c = MetricSummary{Type: m.Type, Values: map[string]float64{}}
+				combined[name] = c
+			}
+
+			switch m.Type {
+			case metrics.Counter.String():
+				c.Values["count"] += m.Values["count"]
+				c.Values["rate"] += m.Values["rate"]
+
+			case metrics.Gauge.String():
+				combineWith(c.Values, m.Values, "value", math.Max)
+
+			case metrics.Trend.String():
+				combineWith(c.Values, m.Values, "min", math.Min)
+				combineWith(c.Values, m.Values, "max", math.Max)
+			}
+		}
+	}
+
+	for name, m := range combined {
+		if len(m.Values) == 0 {
+			delete(combined, name)
+		}
+	}
+
+	return combined
+}
+
+func combineWith(dst, src map[string]float64, key string, f func(float64, float64) float64) {
+	v, ok := src[key]
+	if !ok {
+		return
+	}
+	if prev, found := dst[key]; found {
+		v = f(prev, v)
+	}
+	dst[key] = v
+}
@@ -0,0 +1,47 @@
+package testrun
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	k6api "go.k6.io/k6/v2/api/v1"
+	"go.k6.io/k6/v2/metrics"
+)
+
+func Test_NewSummary(t *testing.T) {
+	summary := NewSummary([]k6api.Metric{
+		{
+			Name:   "http_reqs",
+			Type:   k6api.NullMetricType{Type: metrics.Counter, Valid: true},
+			Sample: map[string]float64{"count": 10, "rate": 1},
+		},
+		{
+			Name: "no_type",
+		},
+	})
+
+	assert.Equal(t, Summary{
+		"http_reqs": {Type: "counter", Values: map[string]float64{"count": 10, "rate": 1}},
+	}, summary)
+}
+
+func Test_CombineSummaries(t *testing.T) {
+	first := Summary{
+		"http_reqs":         {Type: "counter", Values: map[string]float64{"count": 10, "rate": 1}},
+		"vus":               {Type: "gauge", Values: map[string]float64{"value": 5}},
+		"http_req_duration": {Type: "trend", Values: map[string]float64{"min": 10, "max": 200, "avg": 50, "p(95)": 150}},
+		"http_req_failed":   {Type: "rate", Values: map[string]float64{"rate": 0.1}},
+	}
+	second := Summary{
+		"http_reqs":         {Type: "counter", Values: map[string]float64{"count": 20, "rate": 2}},
+		"vus":               {Type: "gauge", Values: map[string]float64{"value": 3}},
+		"http_req_duration": {Type: "trend", Values: map[string]float64{"min": 5, "max": 100, "avg": 40, "p(95)": 90}},
+		"http_req_failed":   {Type: "rate", Values: map[string]float64{"rate": 0.2}},
+	}
+
+	assert.Equal(t, Summary{
+		"http_reqs":         {Type: "counter", Values: map[string]float64{"count": 30, "rate": 3}},
+		"vus":               {Type: "gauge", Values: map[string]float64{"value": 5}},
+		"http_req_duration": {Type: "trend", Values: map[string]float64{"min": 5, "max": 200}},
+	}, CombineSummaries([]Summary{first, second}))
+}
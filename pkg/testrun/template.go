@@ -1,6 +1,8 @@
 package testrun
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 
 	"github.com/grafana/k6-operator/api/v1alpha1"
@@ -18,3 +20,148 @@ func (t *Template) Create() *v1alpha1.TestRun {
 func PLZTestName(testRunId string) string {
 	return fmt.Sprintf("plz-test-%s", testRunId)
 }
+
+// MergeSpec merges the spec of a TestRun with the spec of its template.
+// Fields set in the spec override the template: objects and maps are merged
+// by keys, lists of items with a name are merged by the name, and other lists
+// are replaced. The script is replaced as a whole, since a script combined
+// from two sources would be ambiguous. Empty values, zero and false are
+// treated as unset, so they do not override the template.
+func MergeSpec(template, spec *v1alpha1.TestRunSpec) (*v1alpha1.TestRunSpec, error) {
+	if template.TemplateRef != nil {
+		return nil, errors.New("a template cannot reference another template")
+	}
+
+	base, err := toUnstructured(template)
+	if err != nil {
+		return nil, err
+	}
+	override, err := toUnstructured(spec)
+	if err != nil {
+		return nil, err
+	}
+	delete(override, "templateRef")
+
+	if script, ok := override["script"]; ok {
+		if script = pruneEmpty(script); script != nil {
+			base["script"] = script
+		}
+		delete(override, "script")
+	}
+
+	if override := pruneEmpty(override); override != nil {
+		mergeValues(base, override)
+	}
+
+	data, err := json.Marshal(base)
+	if err != nil {
+		return nil, err
+	}
+	resolved := &v1alpha1.TestRunSpec{}
+	if err := json.Unmarshal(data, resolved); err != nil {
+		return nil, err
+	}
+	return resolved, nil
+}
+
+func toUnstructured(spec *v1alpha1.TestRunSpec) (map[string]interface{}, error) {
+	data, err := json.Marshal(spec)
+	if err != nil {
+		return nil, err
+	}
+	m := map[string]interface{}{}
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// pruneEmpty removes unset values, i.e. empty strings, zero, false, and
+// objects and lists which are empty or become empty once pruned.
+// It returns nil if the value itself is unset.
+func pruneEmpty(v interface{}) interface{} {
+	switch value := v.(type) {
+	case map[string]interface{}:
+		for k, item := range value {
+			if item = pruneEmpty(item); item == nil {
+				delete(value, k)
+			} else {
+				value[k] = item
+			}
+		}
+		if len(value) == 0 {
+			return nil
+		}
+	case []interface{}:
+		if len(value) == 0 {
+			return nil
+		}
+	case string:
+		if len(value) == 0 {
+			return nil
+		}
+	case float64:
+		if value == 0 {
+			return nil
+		}
+	case bool:
+		if !value {
+			return nil
+		}
+	}
+	return v
+}
+
+// mergeValues merges override into base.
+func mergeValues(base, override interface{}) interface{} {
+	switch o := override.(type) {
+	case map[string]interface{}:
+		b, ok := base.(map[string]interface{})
+		if !ok {
+			return o
+		}
+		for k, v := range o {
+			b[k] = mergeValues(b[k], v)
+		}
+		return b
+
+	case []interface{}:
+		b, ok := base.([]interface{})
+		if !ok || !isNamedList(b) || !isNamedList(o) {
+			return o
+		}
+		// Items of the spec replace the items of the template with the same
+		// name; the rest are appended in their order.
+		index := map[string]int{}
+		for i, item := range b {
+			index[itemName(item)] = i
+		}
+		for _, item := range o {
+			if i, ok := index[itemName(item)]; ok {
+				b[i] = item
+			} else {
+				b = append(b, item)
+			}
+		}
+		return b
+	}
+	return override
+}
+
+func isNamedList(list []interface{}) bool {
+	for _, item := range list {
+		if len(itemName(item)) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func itemName(item interface{}) string {
+	m, ok := item.(map[string]interface{})
+	if !ok {
+		return ""
+	}
+	name, _ := m["name"].(string)
+	return name
+}
@@ -5,6 +5,9 @@ import (
 
 	"github.com/grafana/k6-operator/api/v1alpha1"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -38,3 +41,97 @@ func Test_TemplateCreate(t *testing.T) {
 
 	assert.Equal(t, "base", Template(v1alpha1.TestRun(tmpl)).Name)
 }
+
+func Test_MergeSpec(t *testing.T) {
+	template := &v1alpha1.TestRunSpec{
+		Parallelism: 2,
+		Arguments:   "--out experimental-prometheus-rw",
+		Quiet:       "true",
+		Script: v1alpha1.K6Script{
+			ConfigMap: v1alpha1.K6Configmap{Name: "default-test", File: "test.js"},
+		},
+		Runner: v1alpha1.Pod{
+			Image:        "grafana/k6:1.0.0",
+			NodeSelector: map[string]string{"pool": "load", "zone": "a"},
+			Env: []corev1.EnvVar{
+				{Name: "A", Value: "template"},
+				{Name: "B", Value: "template"},
+			},
+			Tolerations: []corev1.Toleration{{Key: "dedicated", Value: "load"}},
+			Resources: corev1.ResourceRequirements{
+				Limits: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("500m"),
+					corev1.ResourceMemory: resource.MustParse("512Mi"),
+				},
+			},
+		},
+	}
+
+	spec := &v1alpha1.TestRunSpec{
+		TemplateRef: &v1alpha1.TestRunTemplateReference{Name: "defaults"},
+		Parallelism: 4,
+		Script: v1alpha1.K6Script{
+			Inline: "export default function () {}",
+		},
+		Runner: v1alpha1.Pod{
+			NodeSelector: map[string]string{"zone": "b"},
+			Env: []corev1.EnvVar{
+				{Name: "B", Value: "testrun"},
+				{Name: "C", Value: "testrun"},
+			},
+			Tolerations: []corev1.Toleration{{Key: "spot", Value: "true"}},
+			Resources: corev1.ResourceRequirements{
+				Limits: corev1.ResourceList{
+					corev1.ResourceCPU: resource.MustParse("1"),
+				},
+			},
+		},
+	}
+
+	resolved, err := MergeSpec(template, spec)
+	require.NoError(t, err)
+
+	// scalars set in the TestRun override the template, unset ones are taken from it
+	assert.Equal(t, int32(4), resolved.Parallelism)
+	assert.Equal(t, "--out experimental-prometheus-rw", resolved.Arguments)
+	assert.Equal(t, "grafana/k6:1.0.0", resolved.Runner.Image)
+	// the script is replaced as a whole
+	assert.Equal(t, v1alpha1.K6Script{Inline: "export default function () {}"}, resolved.Script)
+	// maps are merged by keys
+	assert.Equal(t, map[string]string{"pool": "load", "zone": "b"}, resolved.Runner.NodeSelector)
+	assert.True(t, resolved.Runner.Resources.Limits.Cpu().Equal(resource.MustParse("1")))
+	assert.True(t, resolved.Runner.Resources.Limits.Memory().Equal(resource.MustParse("512Mi")))
+	// lists of named items are merged by name, other lists are replaced
+	assert.Equal(t, []corev1.EnvVar{
+		{Name: "A", Value: "template"},
+		{Name: "B", Value: "testrun"},
+		{Name: "C", Value: "testrun"},
+	}, resolved.Runner.Env)
+	assert.Equal(t, []corev1.Toleration{{Key: "spot", Value: "true"}}, resolved.Runner.Tolerations)
+	// the reference itself is not part of the resolved spec
+	assert.Nil(t, resolved.TemplateRef)
+
+	// neither of the inputs is modified
+	assert.Equal(t, "default-test", template.Script.ConfigMap.Name)
+	assert.Len(t, spec.Runner.Env, 2)
+}
+
+func Test_MergeSpec_EmptyTestRun(t *testing.T) {
+	template := &v1alpha1.TestRunSpec{
+		Parallelism: 2,
+		Script:      v1alpha1.K6Script{ConfigMap: v1alpha1.K6Configmap{Name: "test", File: "test.js"}},
+	}
+
+	resolved, err := MergeSpec(template, &v1alpha1.TestRunSpec{})
+	require.NoError(t, err)
+	assert.Equal(t, template, resolved)
+}
+
+func Test_MergeSpec_NestedTemplate(t *testing.T) {
+	template := &v1alpha1.TestRunSpec{
+		TemplateRef: &v1alpha1.TestRunTemplateReference{Name: "other"},
+	}
+
+	_, err := MergeSpec(template, &v1alpha1.TestRunSpec{})
+	assert.Error(t, err)
+}
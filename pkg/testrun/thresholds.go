@@ -0,0 +1,141 @@
+package testrun
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"go.k6.io/k6/v2/metrics"
+)
+
+// thresholdRegexp follows the threshold syntax of k6:
+// https://grafana.com/docs/k6/latest/using-k6/thresholds/#threshold-syntax
+var thresholdRegexp = regexp.MustCompile(
+	`^\s*(count|rate|value|avg|min|max|med|p\(\s*(\d+(?:\.\d+)?)\s*\))\s*(>=|<=|===|==|!=|>|<)\s*(-?\d+(?:\.\d+)?)\s*$`)
+
+// restPercentiles are the only percentiles of trends exposed by k6 REST API.
+var restPercentiles = []string{"p(90)", "p(95)"}
+
+// Threshold is a parsed threshold expression, e.g. `p(95)<200`.
+type Threshold struct {
+	// Aggregation is the key of the metric value, as in the
+	// output of k6 REST API, e.g. `p(95)` or `count`.
+	Aggregation string
+	Operator    string
+	Value       float64
+}
+
+// ParseThreshold parses a threshold expression of k6.
+func ParseThreshold(source string) (Threshold, error) {
+	m := thresholdRegexp.FindStringSubmatch(source)
+	if m == nil {
+		return Threshold{}, fmt.Errorf("invalid threshold %q", source)
+	}
+
+	aggregation := m[1]
+	if len(m[2]) > 0 {
+		// Normalize the percentile the same way as k6 does, e.g. p(95.0) to p(95).
+		p, err := strconv.ParseFloat(m[2], 64)
+		if err != nil {
+			return Threshold{}, fmt.Errorf("invalid percentile in threshold %q: %w", source, err)
+		}
+		aggregation = fmt.Sprintf("p(%g)", p)
+	}
+
+	value, err := strconv.ParseFloat(m[4], 64)
+	if err != nil {
+		return Threshold{}, fmt.Errorf("invalid value in threshold %q: %w", source, err)
+	}
+
+	return Threshold{Aggregation: aggregation, Operator: m[3], Value: value}, nil
+}
+
+// IsSupported checks if the aggregation of the threshold is exposed by
+// k6 REST API. Other percentiles are known only to each runner, so such
+// a threshold cannot be evaluated across all runners.
+func (t Threshold) IsSupported() bool {
+	return !strings.HasPrefix(t.Aggregation, "p(") || slices.Contains(restPercentiles, t.Aggregation)
+}
+
+// Evaluate checks the threshold against the metric in summaries of all runners.
+// It returns ok == false if no runner has the value, e.g. when the metric
+// has no samples yet or the aggregation is not exposed by k6 REST API.
+//
+// Counters are summed and the minimum and maximum of trends are combined,
+// so these are evaluated exactly. Other values, like averages, rates and
+// percentiles, cannot be combined without the samples themselves, but the
+// combined value is always between the lowest and the highest value of the
+// runners. So the threshold passes only if it holds for each runner: this
+// way, a breach on a single runner is never hidden by the others.
+func (t Threshold) Evaluate(metric string, summaries []Summary) (passed, ok bool) {
+	var values []float64
+	var metricType string
+
+	for _, summary := range summaries {
+		m, found := summary[metric]
+		if !found {
+			continue
+		}
+		v, found := m.Values[t.Aggregation]
+		if !found {
+			continue
+		}
+		metricType = m.Type
+		values = append(values, v)
+	}
+
+	if len(values) == 0 {
+		return false, false
+	}
+
+	switch {
+	case metricType == metrics.Counter.String():
+		var sum float64
+		for _, v := range values {
+			sum += v
+		}
+		values = []float64{sum}
+
+	case t.Aggregation == "min":
+		values = []float64{combine(values, math.Min)}
+
+	case t.Aggregation == "max":
+		values = []float64{combine(values, math.Max)}
+	}
+
+	for _, v := range values {
+		if !t.holds(v) {
+			return false, true
+		}
+	}
+	return true, true
+}
+
+func (t Threshold) holds(v float64) bool {
+	switch t.Operator {
+	case ">":
+		return v > t.Value
+	case ">=":
+		return v >= t.Value
+	case "<=":
+		return v <= t.Value
+	case "<":
+		return v < t.Value
+	case "==", "===":
+		return v == t.Value
+	case "!=":
+		return v != t.Value
+	}
+	return false
+}
+
+func combine(values []float64, f func(float64, float64) float64) float64 {
+	result := values[0]
+	for _, v := range values[1:] {
+		result = f(result, v)
+	}
+	return result
+}
@@ -0,0 +1,98 @@
+package testrun
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ParseThreshold(t *testing.T) {
+	tests := []struct {
+		source   string
+		expected Threshold
+		wantErr  bool
+	}{
+		{source: "p(95)<200", expected: Threshold{Aggregation: "p(95)", Operator: "<", Value: 200}},
+		{source: " p( 99.0 ) <= 1.5 ", expected: Threshold{Aggregation: "p(99)", Operator: "<=", Value: 1.5}},
+		{source: "rate===0", expected: Threshold{Aggregation: "rate", Operator: "===", Value: 0}},
+		{source: "count>-1", expected: Threshold{Aggregation: "count", Operator: ">", Value: -1}},
+		{source: "avg<", wantErr: true},
+		{source: "p95<200", wantErr: true},
+		{source: "sum<200", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.source, func(t *testing.T) {
+			threshold, err := ParseThreshold(tt.source)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, threshold)
+		})
+	}
+}
+
+func Test_ThresholdEvaluate(t *testing.T) {
+	summaries := []Summary{
+		{
+			"http_reqs":         {Type: "counter", Values: map[string]float64{"count": 60, "rate": 1}},
+			"http_req_duration": {Type: "trend", Values: map[string]float64{"min": 10, "max": 150, "p(95)": 100}},
+			"http_req_failed":   {Type: "rate", Values: map[string]float64{"rate": 0.01}},
+		},
+		{
+			"http_reqs":         {Type: "counter", Values: map[string]float64{"count": 60, "rate": 1}},
+			"http_req_duration": {Type: "trend", Values: map[string]float64{"min": 5, "max": 300, "p(95)": 250}},
+			"http_req_failed":   {Type: "rate", Values: map[string]float64{"rate": 0.02}},
+		},
+	}
+
+	tests := []struct {
+		metric     string
+		source     string
+		wantPassed bool
+		wantOk     bool
+	}{
+		{metric: "http_reqs", source: "count>100", wantPassed: true, wantOk: true},
+		{metric: "http_reqs", source: "rate<2", wantPassed: false, wantOk: true},
+		{metric: "http_req_duration", source: "min>=5", wantPassed: true, wantOk: true},
+		{metric: "http_req_duration", source: "max<200", wantPassed: false, wantOk: true},
+		{metric: "http_req_duration", source: "p(95)<200", wantPassed: false, wantOk: true},
+		{metric: "http_req_duration", source: "p(95)<300", wantPassed: true, wantOk: true},
+		{metric: "http_req_failed", source: "rate<0.015", wantPassed: false, wantOk: true},
+		{metric: "http_req_duration", source: "p(99)<300", wantOk: false},
+		{metric: "checks", source: "rate>0.9", wantOk: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.metric+" "+tt.source, func(t *testing.T) {
+			threshold, err := ParseThreshold(tt.source)
+			require.NoError(t, err)
+
+			passed, ok := threshold.Evaluate(tt.metric, summaries)
+			assert.Equal(t, tt.wantOk, ok)
+			assert.Equal(t, tt.wantPassed, passed)
+		})
+	}
+}
+
+func Test_ThresholdIsSupported(t *testing.T) {
+	tests := map[string]bool{
+		"p(95)<200":   true,
+		"p(90.0)<200": true,
+		"p(99)<200":   false,
+		"p(99.9)<200": false,
+		"med<100":     true,
+		"rate<0.01":   true,
+	}
+
+	for source, expected := range tests {
+		t.Run(source, func(t *testing.T) {
+			threshold, err := ParseThreshold(source)
+			require.NoError(t, err)
+			assert.Equal(t, expected, threshold.IsSupported())
+		})
+	}
+}
@@ -0,0 +1,107 @@
+package testrun
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	k6Client "go.k6.io/k6/v2/api/v1/client"
+	"k8s.io/client-go/rest"
+)
+
+// APIPort is the port of k6 REST API in runner pods.
+const APIPort = 6565
+
+const (
+	// RequestTimeout bounds requests to k6 REST API of the runners.
+	RequestTimeout = 30 * time.Second
+	// SetupTimeout bounds the calls of setup() and teardown(), which execute
+	// the code of the script and may take much longer than other requests.
+	SetupTimeout = 10 * time.Minute
+)
+
+// Runner is a runner pod with k6 REST API.
+type Runner struct {
+	// Name of the runner, i.e. the name of its job.
+	Name      string
+	Namespace string
+	Pod       string
+	IP        string
+}
+
+// Transport defines how the operator reaches k6 REST API of the runners.
+// Requests of the returned client time out after the given duration.
+type Transport interface {
+	Client(runner Runner, timeout time.Duration) (*k6Client.Client, error)
+}
+
+// DirectTransport makes requests directly to the IP of the runner pod.
+// It requires the operator to be inside of the pod network.
+type DirectTransport struct{}
+
+func (DirectTransport) Client(runner Runner, timeout time.Duration) (*k6Client.Client, error) {
+	if len(runner.IP) == 0 {
+		return nil, fmt.Errorf("runner %s has no IP yet", runner.Name)
+	}
+	return k6Client.New(net.JoinHostPort(runner.IP, fmt.Sprint(APIPort)), k6Client.WithHTTPClient(&http.Client{
+		Timeout: timeout,
+	}))
+}
+
+// ProxyTransport makes requests via the pod proxy of Kubernetes API server.
+// It works when the pod network cannot be reached from the operator, e.g.
+// when the operator runs outside of the cluster or NetworkPolicies are in place,
+// but requires access to pods/proxy.
+type ProxyTransport struct {
+	server    *url.URL
+	transport http.RoundTripper
+}
+
+// NewProxyTransport creates a ProxyTransport which connects to the API server
+// with the given config.
+func NewProxyTransport(config *rest.Config) (*ProxyTransport, error) {
+	server, _, err := rest.DefaultServerUrlFor(config)
+	if err != nil {
+		return nil, err
+	}
+	transport, err := rest.TransportFor(config)
+	if err != nil {
+		return nil, err
+	}
+	return &ProxyTransport{server: server, transport: transport}, nil
+}
+
+func (t *ProxyTransport) Client(runner Runner, timeout time.Duration) (*k6Client.Client, error) {
+	if len(runner.Pod) == 0 {
+		return nil, fmt.Errorf("runner %s has no pod yet", runner.Name)
+	}
+
+	// The client builds URLs from the host only, so the path of the proxy
+	// is added by the round tripper.
+	return k6Client.New(t.server.Host, k6Client.WithHTTPClient(&http.Client{
+		Timeout: timeout,
+		Transport: &proxyRoundTripper{
+			server: t.server,
+			prefix: fmt.Sprintf("/api/v1/namespaces/%s/pods/%s:%d/proxy", runner.Namespace, runner.Pod, APIPort),
+			next:   t.transport,
+		},
+	}))
+}
+
+type proxyRoundTripper struct {
+	server *url.URL
+	prefix string
+	next   http.RoundTripper
+}
+
+func (rt *proxyRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.URL.Scheme = rt.server.Scheme
+	req.URL.Host = rt.server.Host
+	req.URL.Path = strings.TrimSuffix(rt.server.Path, "/") + rt.prefix + req.URL.Path
+	req.Host = ""
+	return rt.next.RoundTrip(req)
+}
@@ -0,0 +1,58 @@
+package testrun
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/rest"
+)
+
+func Test_ProxyTransport(t *testing.T) {
+	var path string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		_, _ = w.Write([]byte(`{"data":{"type":"status","id":"default","attributes":{"status":7,"running":false,"tainted":true}}}`))
+	}))
+	defer server.Close()
+
+	transport, err := NewProxyTransport(&rest.Config{Host: server.URL + "/prefix"})
+	require.NoError(t, err)
+
+	status, err := GetStatus(context.Background(), transport, Runner{Name: "test-1", Namespace: "default", Pod: "test-1-abcde"})
+	require.NoError(t, err)
+
+	assert.Equal(t, "/prefix/api/v1/namespaces/default/pods/test-1-abcde:6565/proxy/v1/status", path)
+	assert.True(t, status.Tainted)
+	assert.False(t, status.Running)
+}
+
+func Test_TransportWithoutPod(t *testing.T) {
+	_, err := DirectTransport{}.Client(Runner{Name: "test-1"}, RequestTimeout)
+	assert.Error(t, err)
+
+	transport, err := NewProxyTransport(&rest.Config{Host: "https://localhost:6443"})
+	require.NoError(t, err)
+	_, err = transport.Client(Runner{Name: "test-1"}, RequestTimeout)
+	assert.Error(t, err)
+}
+
+func Test_ProxyTransportTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	transport, err := NewProxyTransport(&rest.Config{Host: server.URL})
+	require.NoError(t, err)
+
+	c, err := transport.Client(Runner{Name: "test-1", Namespace: "default", Pod: "test-1-abcde"}, 50*time.Millisecond)
+	require.NoError(t, err)
+
+	_, err = c.Status(context.Background())
+	assert.Error(t, err)
+}
@@ -21,6 +21,18 @@ func UpdateCondition(conditions *[]metav1.Condition, conditionType string, condi
 	})
 }
 
+// SetCondition is like UpdateCondition but with a custom reason and message:
+// it is meant for conditions that can be set for several different reasons.
+func SetCondition(conditions *[]metav1.Condition, conditionType string, conditionStatus metav1.ConditionStatus, reason, message string) {
+	meta.SetStatusCondition(conditions, metav1.Condition{
+		Type:               conditionType,
+		Status:             conditionStatus,
+		LastTransitionTime: metav1.Now(),
+		Reason:             reason,
+		Message:            message,
+	})
+}
+
 // SetIfNewer changes cond only if changes in proposedCond are consistent
 // with the expected change of conditions both logically and chronologically.
 // callbackF can be provided to run a custom function during the loop
@@ -74,6 +86,9 @@ var reasons = map[string]string{
 	"TeardownExecutedFalse":   "TeardownExecutedFalse",
 	"TeardownExecutedTrue":    "TeardownExecutedTrue",
 
+	"SetupSucceededTrue":    "SetupSucceededTrue",
+	"TeardownSucceededTrue": "TeardownSucceededTrue",
+
 	"CloudTestRunUnknown": "TestRunTypeUnknown",
 	"CloudTestRunTrue":    "CloudTestRunTrue",
 	"CloudTestRunFalse":   "CloudTestRunFalse",
@@ -97,4 +112,20 @@ var reasons = map[string]string{
 	"CloudTestRunAbortedUnknown": "CloudTestRunAbortedUnknown",
 	"CloudTestRunAbortedTrue":    "CloudTestRunAbortedTrue",
 	"CloudTestRunAbortedFalse":   "CloudTestRunAbortedFalse",
+
+	"TestRunFailedUnknown": "TestRunFailedUnknown",
+	"TestRunFailedTrue":    "TestRunFailedTrue",
+	"TestRunFailedFalse":   "TestRunFailedFalse",
+
+	"TestRunSucceededUnknown": "TestRunSucceededUnknown",
+	"TestRunSucceededTrue":    "TestRunSucceededTrue",
+	"TestRunSucceededFalse":   "TestRunSucceededFalse",
+
+	"TestRunPausedUnknown": "TestRunPausedUnknown",
+	"TestRunPausedTrue":    "TestRunPausedTrue",
+	"TestRunPausedFalse":   "TestRunPausedFalse",
+
+	"ThresholdsPassedUnknown": "ThresholdsPassedUnknown",
+	"ThresholdsPassedTrue":    "ThresholdsPassedTrue",
+	"ThresholdsPassedFalse":   "ThresholdsPassedFalse",
 }
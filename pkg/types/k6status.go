@@ -15,6 +15,10 @@ type StatusAPIRequestData struct {
 	Type       string                         `json:"type"`
 }
 
+// StatusAPIRequestDataAttributes don't include `vus` and `vus-max`:
+// k6 REST API rejects live VU updates since there is no longer
+// an externally-controlled executor, so runners cannot be scaled
+// while the test is running.
 type StatusAPIRequestDataAttributes struct {
 	Paused  bool `json:"paused"`
 	Stopped bool `json:"stopped"`
@@ -1,18 +1,56 @@
 package types
 
 import (
+	"fmt"
 	"strings"
 
 	corev1 "k8s.io/api/core/v1"
 )
 
+const (
+	// ScriptVolumeName is the name of the volume with the script.
+	ScriptVolumeName = "k6-test-volume"
+
+	// SourceSecretVolumeName is the name of the volume with the credentials
+	// of the script source, mounted at SourceSecretDir.
+	SourceSecretVolumeName = "k6-source-secret"
+	SourceSecretDir        = "/etc/k6-source"
+)
+
 // Internal type created to support Spec.script options
 type Script struct {
 	Name     string // Name of ConfigMap or VolumeClaim or "LocalFile"
 	ReadOnly bool   // VolumeClaim only
 	Filename string
 	Path     string
-	Type     string // ConfigMap | VolumeClaim | LocalFile
+	Type     string        // ConfigMap | VolumeClaim | LocalFile | Git | HTTP | OCI | Inline | Bundle
+	Source   *ScriptSource // Git, HTTP and OCI only
+	Inline   string        // Inline only
+	Bundle   []BundleItem  // Bundle only
+}
+
+// BundleItem is a ConfigMap or a Secret which is laid out in a folder
+// of the script volume, by copying its keys or unpacking its archive.
+type BundleItem struct {
+	ConfigMap string
+	Secret    string
+	Dir       string
+	Archive   string // the key with a gzipped tar
+}
+
+// BundleVolumeName is the name of the volume of i-th bundle item.
+func BundleVolumeName(i int) string {
+	return fmt.Sprintf("k6-bundle-%d", i)
+}
+
+// ScriptSource is the remote location which is fetched into the script volume
+// by an init container.
+type ScriptSource struct {
+	URL        string // URL of the repository or the file, or reference of the OCI artifact
+	Revision   string // Git: a branch, a tag or a commit; empty for the default branch
+	SHA256     string // HTTP: the expected checksum of the file
+	SecretName string
+	Image      string
 }
 
 func (s *Script) FullName() string {
@@ -48,6 +86,39 @@ func (s *Script) Volume() []corev1.Volume {
 			},
 		}
 
+	// Git, HTTP, OCI, Inline, Bundle: the script is fetched into an empty volume by an init container.
+	case "Git", "HTTP", "OCI", "Inline", "Bundle":
+		volumes := []corev1.Volume{
+			corev1.Volume{
+				Name: ScriptVolumeName,
+				VolumeSource: corev1.VolumeSource{
+					EmptyDir: &corev1.EmptyDirVolumeSource{},
+				},
+			},
+		}
+		if s.Source != nil && s.Source.SecretName != "" {
+			volumes = append(volumes, corev1.Volume{
+				Name: SourceSecretVolumeName,
+				VolumeSource: corev1.VolumeSource{
+					Secret: &corev1.SecretVolumeSource{
+						SecretName: s.Source.SecretName,
+					},
+				},
+			})
+		}
+		for i, item := range s.Bundle {
+			volume := corev1.Volume{Name: BundleVolumeName(i)}
+			if len(item.Secret) > 0 {
+				volume.Secret = &corev1.SecretVolumeSource{SecretName: item.Secret}
+			} else {
+				volume.ConfigMap = &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{Name: item.ConfigMap},
+				}
+			}
+			volumes = append(volumes, volume)
+		}
+		return volumes
+
 	default:
 		return []corev1.Volume{}
 	}
@@ -69,7 +140,8 @@ func (s *Script) VolumeMount() []corev1.VolumeMount {
 		}
 
 	// ConfigMap: always mounted at "/test" since keys cannot represent nested directories.
-	case "ConfigMap":
+	// Git, HTTP, OCI, Inline, Bundle: the script is fetched into "/test", and s.Path is a folder inside of it.
+	case "ConfigMap", "Git", "HTTP", "OCI", "Inline", "Bundle":
 		return []corev1.VolumeMount{
 			corev1.VolumeMount{
 				Name:      "k6-test-volume",
@@ -44,6 +44,68 @@ func Test_Script_Volume(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:   "Git",
+			script: Script{Type: "Git", Name: "https://example.com/tests.git", Source: &ScriptSource{}},
+			expected: []corev1.Volume{
+				{
+					Name: "k6-test-volume",
+					VolumeSource: corev1.VolumeSource{
+						EmptyDir: &corev1.EmptyDirVolumeSource{},
+					},
+				},
+			},
+		},
+		{
+			name:   "Git with credentials",
+			script: Script{Type: "Git", Name: "https://example.com/tests.git", Source: &ScriptSource{SecretName: "creds"}},
+			expected: []corev1.Volume{
+				{
+					Name: "k6-test-volume",
+					VolumeSource: corev1.VolumeSource{
+						EmptyDir: &corev1.EmptyDirVolumeSource{},
+					},
+				},
+				{
+					Name: "k6-source-secret",
+					VolumeSource: corev1.VolumeSource{
+						Secret: &corev1.SecretVolumeSource{
+							SecretName: "creds",
+						},
+					},
+				},
+			},
+		},
+		{
+			name:   "Bundle",
+			script: Script{Type: "Bundle", Name: "Bundle", Bundle: []BundleItem{{ConfigMap: "main"}, {Secret: "modules"}}},
+			expected: []corev1.Volume{
+				{
+					Name: "k6-test-volume",
+					VolumeSource: corev1.VolumeSource{
+						EmptyDir: &corev1.EmptyDirVolumeSource{},
+					},
+				},
+				{
+					Name: "k6-bundle-0",
+					VolumeSource: corev1.VolumeSource{
+						ConfigMap: &corev1.ConfigMapVolumeSource{
+							LocalObjectReference: corev1.LocalObjectReference{
+								Name: "main",
+							},
+						},
+					},
+				},
+				{
+					Name: "k6-bundle-1",
+					VolumeSource: corev1.VolumeSource{
+						Secret: &corev1.SecretVolumeSource{
+							SecretName: "modules",
+						},
+					},
+				},
+			},
+		},
 		{
 			name:     "no type (should be blocked by early validation)",
 			script:   Script{Name: "test"},